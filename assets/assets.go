@@ -0,0 +1,213 @@
+package assets
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/GopherGhaznix/Wave/html"
+)
+
+// unexported key type ensures uniqueness
+type manifestContextKey struct{}
+
+// Asset describes a single static file.
+type Asset struct {
+	Name       string // path relative to the asset root, e.g. "js/app.js"
+	HashedName string // content-hashed path, e.g. "js/app.1a2b3c4d.js"
+	Integrity  string // SRI hash, e.g. "sha384-..."
+}
+
+// Manifest maps asset names to their hashed names and SRI hashes.
+type Manifest struct {
+	// Prefix is the URL path the assets are served under, e.g. "/static/".
+	Prefix string
+
+	fsys   fs.FS
+	assets map[string]Asset
+	hashed map[string]string // hashed name -> name
+}
+
+// New reads every file in fsys (a directory or an embed.FS) and computes
+// its SRI hash and content-hashed name.
+//
+// Example:
+//
+//	//go:embed static
+//	var static embed.FS
+//
+//	sub, _ := fs.Sub(static, "static")
+//	m, err := assets.New(sub, "/static/")
+func New(fsys fs.FS, prefix string) (*Manifest, error) {
+	m := &Manifest{
+		Prefix: prefix,
+		fsys:   fsys,
+		assets: map[string]Asset{},
+		hashed: map[string]string{},
+	}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		asset := newAsset(name, content)
+		m.assets[name] = asset
+		m.hashed[asset.HashedName] = name
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("assets: %w", err)
+	}
+	return m, nil
+}
+
+// FromDir is New over a directory on disk.
+func FromDir(dir, prefix string) (*Manifest, error) {
+	return New(os.DirFS(dir), prefix)
+}
+
+// newAsset hashes content once for both the SRI hash and the file name.
+func newAsset(name string, content []byte) Asset {
+	sum := sha512.Sum384(content)
+	ext := path.Ext(name)
+	return Asset{
+		Name:       name,
+		HashedName: strings.TrimSuffix(name, ext) + "." + hex.EncodeToString(sum[:4]) + ext,
+		Integrity:  "sha384-" + base64.StdEncoding.EncodeToString(sum[:]),
+	}
+}
+
+// Lookup returns the asset with the given name.
+func (m *Manifest) Lookup(name string) (Asset, bool) {
+	a, ok := m.assets[strings.TrimPrefix(name, "/")]
+	return a, ok
+}
+
+// Assets returns every asset in the manifest, sorted by name.
+func (m *Manifest) Assets() []Asset {
+	out := make([]Asset, 0, len(m.assets))
+	for _, name := range slices.Sorted(maps.Keys(m.assets)) {
+		out = append(out, m.assets[name])
+	}
+	return out
+}
+
+// Open opens the named asset, by plain or hashed name.
+func (m *Manifest) Open(name string) (fs.File, error) {
+	if plain, ok := m.hashed[name]; ok {
+		name = plain
+	}
+	return m.fsys.Open(name)
+}
+
+// URL returns the cache-busted URL of an asset, or the plain one if the
+// asset is unknown.
+func (m *Manifest) URL(name string) string {
+	if a, ok := m.Lookup(name); ok {
+		return m.Prefix + a.HashedName
+	}
+	return m.Prefix + strings.TrimPrefix(name, "/")
+}
+
+// Handler serves the assets under their hashed names, with long-lived
+// cache headers, and under their plain names. Mount it at Prefix:
+//
+//	http.Handle("/static/", http.StripPrefix("/static/", m.Handler()))
+func (m *Manifest) Handler() http.Handler {
+	files := http.FileServerFS(m.fsys)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name, ok := m.hashed[strings.TrimPrefix(r.URL.Path, "/")]; ok {
+			// Only a file that opens may be cached for good; an error
+			// response must stay revalidatable.
+			if m.servable(name) {
+				w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+			}
+			r2 := r.Clone(r.Context())
+			r2.URL.Path = "/" + name
+			files.ServeHTTP(w, r2)
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+}
+
+// servable reports whether name opens as a regular file.
+func (m *Manifest) servable(name string) bool {
+	f, err := m.fsys.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	return err == nil && !info.IsDir()
+}
+
+// -----------------------
+// Context
+// -----------------------
+
+// WithManifest returns a new context carrying the asset manifest.
+func WithManifest(ctx context.Context, m *Manifest) context.Context {
+	return context.WithValue(ctx, manifestContextKey{}, m)
+}
+
+// ManifestFromContext retrieves the asset manifest from context, if set.
+func ManifestFromContext(ctx context.Context) (*Manifest, bool) {
+	m, ok := ctx.Value(manifestContextKey{}).(*Manifest)
+	return m, ok && m != nil
+}
+
+// -----------------------
+// Nodes
+// -----------------------
+
+// sriAttrs returns the URL attribute and SRI attributes for an asset.
+// Without a manifest, or for an unknown asset, only the URL is set.
+func sriAttrs(c context.Context, urlAttr, name string) html.Attrs {
+	m, ok := ManifestFromContext(c)
+	if !ok {
+		return html.Attrs{urlAttr: name}
+	}
+	a, ok := m.Lookup(name)
+	if !ok {
+		return html.Attrs{urlAttr: m.URL(name)}
+	}
+	return html.Attributes(
+		html.Attrs{urlAttr: m.URL(name)},
+		html.AttrIntegrity(a.Integrity),
+		html.AttrCrossOrigin("anonymous"),
+	)
+}
+
+// Script renders a <script> loading the named asset from the manifest
+// in context, with its hashed URL and integrity hash.
+func Script(c context.Context, name string, attrs ...html.Attrs) html.Node {
+	all := append([]html.Attrs{sriAttrs(c, "src", name)}, attrs...)
+	return html.Script(c, html.Attributes(all...))
+}
+
+// Stylesheet renders a <link rel="stylesheet"> for the named asset from
+// the manifest in context, with its hashed URL and integrity hash.
+func Stylesheet(c context.Context, name string, attrs ...html.Attrs) html.Node {
+	all := append([]html.Attrs{sriAttrs(c, "href", name), html.AttrRel("stylesheet")}, attrs...)
+	return html.Link(c, html.Attributes(all...))
+}
+
+// Preload renders a <link rel="preload"> for the named asset, as the
+// given destination ("script", "style", "font", ...).
+func Preload(c context.Context, name, as string, attrs ...html.Attrs) html.Node {
+	all := append([]html.Attrs{sriAttrs(c, "href", name), html.AttrRel("preload"), html.AttrAs(as)}, attrs...)
+	return html.Link(c, html.Attributes(all...))
+}
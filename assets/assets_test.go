@@ -0,0 +1,46 @@
+package assets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHandlerCacheControl(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.js":  {Data: []byte("console.log(1)")},
+		"gone.js": {Data: []byte("console.log(2)")},
+	}
+	m, err := New(fsys, "/static/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app, _ := m.Lookup("app.js")
+	gone, _ := m.Lookup("gone.js")
+	delete(fsys, "gone.js")
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		cache  string
+	}{
+		{"hashed", "/" + app.HashedName, http.StatusOK, "public, max-age=31536000, immutable"},
+		{"plain", "/app.js", http.StatusOK, ""},
+		{"hashed but missing", "/" + gone.HashedName, http.StatusNotFound, ""},
+		{"unknown", "/nope.js", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.cache {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.cache)
+			}
+		})
+	}
+}
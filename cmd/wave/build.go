@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+)
+
+// runBuild runs a site program, a main package calling site.Main with
+// its route table. Only the flags given on the command line are passed
+// through, so the program's own Config supplies the rest.
+func runBuild(args []string) error {
+	flags := flag.NewFlagSet("build", flag.ContinueOnError)
+	flags.String("out", "", "output directory (default: the program's, or dist)")
+	flags.String("base-url", "", "public origin for sitemap.xml (default: the program's)")
+	flags.Bool("incremental", false, "only write changed files (default: the program's)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	pkg := "."
+	if flags.NArg() > 0 {
+		pkg = flags.Arg(0)
+	}
+
+	runArgs := []string{"run", pkg}
+	flags.Visit(func(f *flag.Flag) {
+		runArgs = append(runArgs, "-"+f.Name+"="+f.Value.String())
+	})
+
+	cmd := exec.Command("go", runArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/GopherGhaznix/Wave/html"
+)
+
+// runClasses statically scans Go sources for class lists: arguments to
+// AttrClass and "class" values in Attrs literals, including themes.
+// Rules passed to WithScheme with a literal scheme name are also
+// recorded with that name as prefix ("dark:bg-gray-900"). Classes
+// computed at runtime, or schemes built without WithScheme, are not
+// seen; use html.ClassCollector, or ClassCollector.AddTheme, for those.
+func runClasses(args []string) error {
+	flags := flag.NewFlagSet("classes", flag.ContinueOnError)
+	format := flags.String("format", "lines", `output format: "lines" (one class per line) or "tailwind" (@source inline)`)
+	out := flags.String("o", "", "write to file instead of stdout")
+	defaultTheme := flags.Bool("default-theme", false, "include the classes of html.NewDefaultTheme")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	roots := flags.Args()
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	col := html.NewClassCollector()
+	if *defaultTheme {
+		col.AddTheme(html.NewDefaultTheme())
+	}
+	for _, root := range roots {
+		if err := scanClasses(strings.TrimSuffix(root, "/..."), col); err != nil {
+			return err
+		}
+	}
+
+	var text string
+	switch *format {
+	case "lines":
+		text = col.Safelist()
+	case "tailwind":
+		text = col.TailwindSource()
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	if *out == "" {
+		_, err := fmt.Print(text)
+		return err
+	}
+	return os.WriteFile(*out, []byte(text), 0o644)
+}
+
+// scanClasses walks root and records class lists from every .go file.
+func scanClasses(root string, col *html.ClassCollector) error {
+	fset := token.NewFileSet()
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				switch name := calleeName(n.Fun); {
+				case name == "AttrClass" && len(n.Args) == 1:
+					addLiteral(col, n.Args[0])
+				case name == "WithScheme" && len(n.Args) == 2:
+					addSchemeClasses(col, n.Args[0], n.Args[1])
+				}
+			case *ast.KeyValueExpr:
+				if key, ok := stringLiteral(n.Key); ok && key == "class" {
+					addLiteral(col, n.Value)
+				}
+			}
+			return true
+		})
+		return nil
+	})
+}
+
+// addSchemeClasses records the classes of a WithScheme rule map with
+// the scheme name as variant prefix, as SchemeVariant renders them. The
+// bare classes are recorded by the general scan, covering SchemeServer.
+func addSchemeClasses(col *html.ClassCollector, nameExpr, rules ast.Expr) {
+	name, ok := stringLiteral(nameExpr)
+	if !ok {
+		return
+	}
+	prefixed := html.NewClassCollector()
+	ast.Inspect(rules, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if calleeName(n.Fun) == "AttrClass" && len(n.Args) == 1 {
+				addLiteral(prefixed, n.Args[0])
+			}
+		case *ast.KeyValueExpr:
+			if key, ok := stringLiteral(n.Key); ok && key == "class" {
+				addLiteral(prefixed, n.Value)
+			}
+		}
+		return true
+	})
+	for _, class := range prefixed.Classes() {
+		col.Add(name + ":" + class)
+	}
+}
+
+func calleeName(fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.SelectorExpr:
+		return f.Sel.Name
+	}
+	return ""
+}
+
+func addLiteral(col *html.ClassCollector, expr ast.Expr) {
+	if s, ok := stringLiteral(expr); ok {
+		col.Add(s)
+	}
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GopherGhaznix/Wave/html"
+	"github.com/GopherGhaznix/Wave/sse"
+)
+
+// liveReloadPath is where the dev server streams reload events.
+const liveReloadPath = "/_wave/live-reload"
+
+// runDev rebuilds and restarts a program whenever a file under the watch
+// directory changes, serves it locally and tells open pages to reload.
+//
+// By default the program is a web server listening on $PORT, proxied by
+// the dev server. With -stdout it is a page printer, like the examples,
+// and its output is served at "/".
+func runDev(args []string) error {
+	flags := flag.NewFlagSet("dev", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:3000", "address to serve on")
+	watch := flags.String("watch", ".", "directory to watch for changes")
+	stdout := flags.Bool("stdout", false, "serve the program's output instead of proxying to it")
+	interval := flags.Duration("interval", 500*time.Millisecond, "how often to check for changes")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	pkg := "."
+	if flags.NArg() > 0 {
+		pkg = flags.Arg(0)
+	}
+
+	tmp, err := os.MkdirTemp("", "wave-dev-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	d := &devServer{
+		pkg:     pkg,
+		bin:     filepath.Join(tmp, "app"),
+		stdout:  *stdout,
+		clients: map[chan struct{}]bool{},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	defer d.stopApp()
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: d}
+	go srv.Serve(ln)
+	defer srv.Close()
+	log.Printf("wave dev: serving %s on http://%s", pkg, ln.Addr())
+
+	d.rebuild()
+	state, _ := snapshot(*watch)
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			next, err := snapshot(*watch)
+			if err != nil || next == state {
+				continue
+			}
+			state = next
+			d.rebuild()
+		}
+	}
+}
+
+// snapshot fingerprints the files under root by name, size and mtime.
+// Polling keeps the dev server free of dependencies and platform quirks.
+func snapshot(root string) (string, error) {
+	var sb strings.Builder
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules" || name == "dist") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&sb, "%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return sb.String(), err
+}
+
+// devServer serves the current build and the live-reload stream.
+type devServer struct {
+	pkg    string
+	bin    string
+	stdout bool
+
+	mu      sync.Mutex
+	app     *exec.Cmd
+	target  *httputil.ReverseProxy
+	page    []byte
+	failure string
+	clients map[chan struct{}]bool
+}
+
+// rebuild compiles the program, restarts it and notifies the browsers.
+// A failed build keeps the previous program running.
+func (d *devServer) rebuild() {
+	start := time.Now()
+	out, err := exec.Command("go", "build", "-o", d.bin, d.pkg).CombinedOutput()
+	if err != nil {
+		log.Printf("wave dev: build failed:\n%s", out)
+		d.mu.Lock()
+		d.failure = string(out)
+		d.mu.Unlock()
+		d.notify()
+		return
+	}
+
+	if d.stdout {
+		err = d.render()
+	} else {
+		err = d.restart()
+	}
+	if err != nil {
+		log.Printf("wave dev: %v", err)
+		return
+	}
+	log.Printf("wave dev: rebuilt in %s", time.Since(start).Round(time.Millisecond))
+	d.notify()
+}
+
+// render runs a page printer and keeps its output.
+func (d *devServer) render() error {
+	cmd := exec.Command(d.bin)
+	cmd.Env = append(os.Environ(), html.LiveReloadEnv+"="+liveReloadPath)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("run: %w", err)
+	}
+	// Fragments have no <body> to carry the script, so append it
+	if !strings.Contains(string(out), liveReloadPath) {
+		c := html.WithIDGenerator(context.Background(), html.SequentialIDs("wave-dev"))
+		out = append(out, html.Script(c, nil, html.Text(liveReloadJS))()...)
+	}
+
+	d.mu.Lock()
+	d.page, d.failure = out, ""
+	d.mu.Unlock()
+	return nil
+}
+
+// restart stops the running server and starts the new build on a free
+// local port, waiting until it accepts connections.
+func (d *devServer) restart() error {
+	d.stopApp()
+
+	port, err := freePort()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(d.bin)
+	cmd.Env = append(os.Environ(), "PORT="+port, html.LiveReloadEnv+"="+liveReloadPath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort("localhost", port)
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(50 * time.Millisecond) {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			cmd.Process.Kill()
+			return fmt.Errorf("program did not listen on $PORT (%s)", port)
+		}
+	}
+
+	d.mu.Lock()
+	d.app = cmd
+	d.target = httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: addr})
+	d.failure = ""
+	d.mu.Unlock()
+	return nil
+}
+
+// stopApp interrupts the running server, killing it if it lingers.
+func (d *devServer) stopApp() {
+	d.mu.Lock()
+	cmd := d.app
+	d.app, d.target = nil, nil
+	d.mu.Unlock()
+	if cmd == nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() { cmd.Wait(); close(done) }()
+	cmd.Process.Signal(os.Interrupt)
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		cmd.Process.Kill()
+		<-done
+	}
+}
+
+func freePort() (string, error) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return "", err
+	}
+	defer ln.Close()
+	_, port, err := net.SplitHostPort(ln.Addr().String())
+	return port, err
+}
+
+// notify tells every connected page to reload.
+func (d *devServer) notify() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for ch := range d.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (d *devServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == liveReloadPath {
+		d.serveLiveReload(w, r)
+		return
+	}
+
+	d.mu.Lock()
+	failure, page, target := d.failure, d.page, d.target
+	d.mu.Unlock()
+
+	switch {
+	case failure != "":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		c := html.WithLiveReload(context.Background(), liveReloadPath)
+		fmt.Fprint(w, html.Body(c, nil,
+			html.H1(c, nil, html.Text("Build failed")),
+			html.Pre(c, nil, html.Text(htmlEscaper.Replace(failure))),
+		)())
+	case d.stdout && r.URL.Path == "/":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	case target != nil:
+		target.ServeHTTP(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// liveReloadJS reloads a served fragment, see html.WithLiveReload.
+const liveReloadJS = `new EventSource("` + liveReloadPath + `").addEventListener("reload", () => location.reload());`
+
+// htmlEscaper escapes build output shown on the error page.
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// serveLiveReload streams a "reload" Server-Sent Event after each build.
+func (d *devServer) serveLiveReload(w http.ResponseWriter, r *http.Request) {
+	stream, err := sse.NewWriter(w)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	stop := stream.Heartbeat(r.Context(), 15*time.Second)
+	defer stop()
+
+	ch := make(chan struct{}, 1)
+	d.mu.Lock()
+	d.clients[ch] = true
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		delete(d.clients, ch)
+		d.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			if stream.Send(sse.Event{Event: "reload"}) != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Command wave is the Wave toolbox.
+//
+// Usage:
+//
+//	wave classes [flags] [packages]   print the classes used in Go sources
+//	wave build [flags] [package]      render a site program to static files
+//	wave dev [flags] [package]        serve a program, rebuilding on changes
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"classes", "print the classes used in Go sources", runClasses},
+	{"build", "render a site program to static files", runBuild},
+	{"dev", "serve a program, rebuilding on changes", runDev},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == os.Args[1] {
+			if err := cmd.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "wave %s: %v\n", cmd.name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "wave: unknown command %q\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: wave <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
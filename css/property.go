@@ -0,0 +1,172 @@
+package css
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// -----------------------
+// Typed Property Builders
+// -----------------------
+
+// Each builder returns a single-declaration Style; combine them with Styles.
+//
+// Example:
+//
+//	css.Styles(
+//	  css.Padding(css.Px(30)),
+//	  css.Height(css.Vh(90)),
+//	  css.Border(css.Px(1), css.Solid, css.Hex("#eee")),
+//	)
+
+// Styles combines several styles into one; later declarations win.
+func Styles(styles ...Style) Style { return Style{}.Merge(styles...) }
+
+// Prop is the escape hatch for properties or values without a typed
+// builder. The property name is not checked; see Style.Validate.
+func Prop(name, value string) Style { return Style{name: value} }
+
+func lengths(values []Length) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = string(v)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Box model (Padding and Margin take 1 to 4 values, like the CSS shorthand)
+func Padding(values ...Length) Style { return Style{"padding": lengths(values)} }
+func PaddingTop(v Length) Style      { return Style{"padding-top": string(v)} }
+func PaddingRight(v Length) Style    { return Style{"padding-right": string(v)} }
+func PaddingBottom(v Length) Style   { return Style{"padding-bottom": string(v)} }
+func PaddingLeft(v Length) Style     { return Style{"padding-left": string(v)} }
+func Margin(values ...Length) Style  { return Style{"margin": lengths(values)} }
+func MarginTop(v Length) Style       { return Style{"margin-top": string(v)} }
+func MarginRight(v Length) Style     { return Style{"margin-right": string(v)} }
+func MarginBottom(v Length) Style    { return Style{"margin-bottom": string(v)} }
+func MarginLeft(v Length) Style      { return Style{"margin-left": string(v)} }
+
+// Sizing
+func Width(v Length) Style     { return Style{"width": string(v)} }
+func Height(v Length) Style    { return Style{"height": string(v)} }
+func MinWidth(v Length) Style  { return Style{"min-width": string(v)} }
+func MaxWidth(v Length) Style  { return Style{"max-width": string(v)} }
+func MinHeight(v Length) Style { return Style{"min-height": string(v)} }
+func MaxHeight(v Length) Style { return Style{"max-height": string(v)} }
+
+// Positioning
+func Position(k Keyword) Style { return Style{"position": string(k)} }
+func Top(v Length) Style       { return Style{"top": string(v)} }
+func Right(v Length) Style     { return Style{"right": string(v)} }
+func Bottom(v Length) Style    { return Style{"bottom": string(v)} }
+func Left(v Length) Style      { return Style{"left": string(v)} }
+func ZIndex(n int) Style       { return Style{"z-index": strconv.Itoa(n)} }
+
+// Layout
+func Display(k Keyword) Style        { return Style{"display": string(k)} }
+func Overflow(k Keyword) Style       { return Style{"overflow": string(k)} }
+func FlexDirection(k Keyword) Style  { return Style{"flex-direction": string(k)} }
+func FlexWrap(k Keyword) Style       { return Style{"flex-wrap": string(k)} }
+func JustifyContent(k Keyword) Style { return Style{"justify-content": string(k)} }
+func AlignItems(k Keyword) Style     { return Style{"align-items": string(k)} }
+func Gap(values ...Length) Style     { return Style{"gap": lengths(values)} }
+
+// Typography
+func Color(c ColorValue) Style     { return Style{"color": string(c)} }
+func FontSize(v Length) Style      { return Style{"font-size": string(v)} }
+func FontWeight(k Keyword) Style   { return Style{"font-weight": string(k)} }
+func FontWeightNumber(n int) Style { return Style{"font-weight": strconv.Itoa(n)} }
+func FontStyle(k Keyword) Style    { return Style{"font-style": string(k)} }
+func FontFamily(families ...string) Style {
+	return Style{"font-family": strings.Join(families, ", ")}
+}
+func LineHeight(v Length) Style { return Style{"line-height": string(v)} }
+func TextAlign(k Keyword) Style { return Style{"text-align": string(k)} }
+
+// Backgrounds & borders
+func BackgroundColor(c ColorValue) Style { return Style{"background-color": string(c)} }
+func Border(width Length, style Keyword, c ColorValue) Style {
+	return Style{"border": fmt.Sprintf("%s %s %s", width, style, c)}
+}
+func BorderColor(c ColorValue) Style { return Style{"border-color": string(c)} }
+func BorderRadius(v Length) Style    { return Style{"border-radius": string(v)} }
+
+// Effects
+func Opacity(n float64) Style { return Style{"opacity": num(n)} }
+
+// -----------------------
+// Validation
+// -----------------------
+
+// Validate reports properties that are not known CSS properties,
+// catching typos like "heigth". Custom properties (--*) and vendor
+// prefixed properties (-webkit-*) are always accepted.
+func (s Style) Validate() error {
+	var errs []error
+	for _, k := range s.sortedKeys() {
+		if !IsKnownProperty(k) {
+			errs = append(errs, fmt.Errorf("css: unknown property %q", k))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// IsKnownProperty reports whether name is a known CSS property.
+func IsKnownProperty(name string) bool {
+	if strings.HasPrefix(name, "--") || strings.HasPrefix(name, "-") {
+		return true
+	}
+	_, ok := knownProperties[strings.ToLower(name)]
+	return ok
+}
+
+var knownProperties = map[string]struct{}{}
+
+func init() {
+	for _, p := range strings.Fields(`
+		accent-color align-content align-items align-self all animation animation-delay
+		animation-direction animation-duration animation-fill-mode animation-iteration-count
+		animation-name animation-play-state animation-timing-function appearance aspect-ratio
+		backdrop-filter backface-visibility background background-attachment background-blend-mode
+		background-clip background-color background-image background-origin background-position
+		background-repeat background-size block-size border border-block border-bottom
+		border-bottom-color border-bottom-left-radius border-bottom-right-radius border-bottom-style
+		border-bottom-width border-collapse border-color border-image border-inline border-left
+		border-left-color border-left-style border-left-width border-radius border-right
+		border-right-color border-right-style border-right-width border-spacing border-style
+		border-top border-top-color border-top-left-radius border-top-right-radius border-top-style
+		border-top-width border-width bottom box-shadow box-sizing break-after break-before
+		break-inside caption-side caret-color clear clip-path color color-scheme column-count
+		column-gap column-rule column-span column-width columns contain container content
+		content-visibility counter-increment counter-reset cursor direction display empty-cells
+		fill filter flex flex-basis flex-direction flex-flow flex-grow flex-shrink flex-wrap float
+		font font-family font-feature-settings font-kerning font-size font-stretch font-style
+		font-variant font-variant-numeric font-weight gap grid grid-area grid-auto-columns
+		grid-auto-flow grid-auto-rows grid-column grid-column-end grid-column-start grid-row
+		grid-row-end grid-row-start grid-template grid-template-areas grid-template-columns
+		grid-template-rows height hyphens image-rendering inline-size inset inset-block
+		inset-inline isolation justify-content justify-items justify-self left letter-spacing
+		line-break line-clamp line-height list-style list-style-image list-style-position
+		list-style-type margin margin-block margin-block-end margin-block-start margin-bottom
+		margin-inline margin-inline-end margin-inline-start margin-left margin-right margin-top
+		mask max-block-size max-height max-inline-size max-width min-block-size min-height
+		min-inline-size min-width mix-blend-mode object-fit object-position opacity order
+		outline outline-color outline-offset outline-style outline-width overflow overflow-wrap
+		overflow-x overflow-y overscroll-behavior padding padding-block padding-block-end
+		padding-block-start padding-bottom padding-inline padding-inline-end padding-inline-start
+		padding-left padding-right padding-top place-content place-items place-self
+		pointer-events position quotes resize right rotate row-gap scale scroll-behavior
+		scroll-margin scroll-padding scroll-snap-align scroll-snap-type scrollbar-color
+		scrollbar-gutter scrollbar-width stroke stroke-width tab-size table-layout text-align
+		text-align-last text-decoration text-decoration-color text-decoration-line
+		text-decoration-style text-decoration-thickness text-indent text-overflow text-shadow
+		text-transform text-underline-offset text-wrap top touch-action transform transform-origin
+		transition transition-delay transition-duration transition-property
+		transition-timing-function translate unicode-bidi user-select vertical-align visibility
+		white-space width will-change word-break word-spacing writing-mode z-index zoom
+	`) {
+		knownProperties[p] = struct{}{}
+	}
+}
@@ -0,0 +1,261 @@
+package css
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// -----------------------
+// Injection Safety
+// -----------------------
+
+// UnsafeDeclarationError reports a declaration rejected by CheckDeclaration.
+type UnsafeDeclarationError struct {
+	Property string
+	Value    string
+	Reason   string
+}
+
+func (e *UnsafeDeclarationError) Error() string {
+	return fmt.Sprintf("css: unsafe declaration %q: %s", e.Property+":"+e.Value, e.Reason)
+}
+
+// dangerousPatterns are rejected anywhere in a value once CSS escapes,
+// comments and whitespace are removed, so "expr\65ssion(" is caught too.
+var dangerousPatterns = []string{
+	"expression(",  // legacy IE script execution
+	"javascript:",  // url(javascript:...)
+	"vbscript:",    // url(vbscript:...)
+	"-moz-binding", // legacy Firefox XBL
+	"behavior:",    // legacy IE HTC
+	"@import",
+}
+
+// CheckDeclaration reports whether "property: value" is safe to emit in
+// an inline style or stylesheet. Values from user data (e.g. a chosen
+// color) must not end the declaration or the block (";", "{", "}"),
+// leave quotes or parentheses open, break out of the surrounding HTML
+// ("<"), or run script through expression() or javascript: URLs.
+func CheckDeclaration(property, value string) error {
+	fail := func(reason string) error {
+		return &UnsafeDeclarationError{Property: property, Value: value, Reason: reason}
+	}
+
+	if !isIdent(property) {
+		return fail("invalid property name")
+	}
+
+	if reason := checkStructure(value); reason != "" {
+		return fail(reason)
+	}
+
+	// The property is included to catch "-moz-binding: ..." and "behavior: ..."
+	normalized := normalizeValue(property + ":" + value)
+	for _, p := range dangerousPatterns {
+		if strings.Contains(normalized, p) {
+			return fail(p + " not allowed")
+		}
+	}
+	return nil
+}
+
+// UnsafeSelectorError reports a selector or at-rule rejected by CheckSelector.
+type UnsafeSelectorError struct {
+	Selector string
+	Reason   string
+}
+
+func (e *UnsafeSelectorError) Error() string {
+	return fmt.Sprintf("css: unsafe selector %q: %s", e.Selector, e.Reason)
+}
+
+// CheckSelector reports whether a selector, or an at-rule prelude such
+// as "@media (min-width: 640px)", is safe to emit in a stylesheet. Like
+// declaration values, it must not end the rule (";", "{", "}"), leave
+// quotes or parentheses open, or break out of the <style> element ("<").
+func CheckSelector(selector string) error {
+	if strings.TrimSpace(selector) == "" {
+		return &UnsafeSelectorError{Selector: selector, Reason: "empty selector"}
+	}
+	if reason := checkStructure(selector); reason != "" {
+		return &UnsafeSelectorError{Selector: selector, Reason: reason}
+	}
+	return nil
+}
+
+// checkStructure returns why s could end the surrounding declaration,
+// rule or HTML element, or "" if it cannot. Delimiters inside quotes
+// are fine, except "<", which the HTML parser sees regardless.
+func checkStructure(s string) string {
+	depth := 0
+	var quote rune
+	escaped := false
+	for _, r := range s {
+		switch {
+		case r < 0x20 && r != '\t' || r == 0x7f:
+			return "control character"
+		case r == '<':
+			return `"<" not allowed`
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth == 0 {
+				return "unbalanced parenthesis"
+			}
+			depth--
+		case r == ';' || r == '{' || r == '}':
+			return strconv.QuoteRune(r) + " not allowed"
+		}
+	}
+	if quote != 0 || escaped {
+		return "unterminated string"
+	}
+	if depth != 0 {
+		return "unbalanced parenthesis"
+	}
+	return ""
+}
+
+// Quote returns s as a double-quoted CSS string, escaping quotes,
+// backslashes, control characters and "<", so it can be embedded in a
+// stylesheet or a <style> element as is.
+//
+// Example:
+//
+//	css.Quote(`say "hi"`) // "say \"hi\""
+func Quote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r < 0x20 || r == 0x7f || r == '<':
+			// Hex escapes end with a space, which is not part of the value
+			fmt.Fprintf(&sb, "\\%x ", r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// Check reports every unsafe declaration in s, which Inline drops (see
+// CheckDeclaration).
+func (s Style) Check() error {
+	var errs []error
+	for _, k := range s.sortedKeys() {
+		if err := CheckDeclaration(k, s[k]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SafeInline renders s like Inline, but fails instead of dropping
+// unsafe declarations, for callers that want to surface the error.
+func (s Style) SafeInline() (string, error) {
+	if err := s.Check(); err != nil {
+		return "", err
+	}
+	return s.Inline(), nil
+}
+
+// Check reports every unsafe declaration, selector and at-rule in the
+// stylesheet, which String drops (see CheckDeclaration and CheckSelector).
+func (s *Stylesheet) Check() error {
+	return errors.Join(checkStatements(s.Statements)...)
+}
+
+func checkStatements(statements []Statement) []error {
+	var errs []error
+	for _, st := range statements {
+		errs = append(errs, st.check()...)
+	}
+	return errs
+}
+
+func (s Style) sortedKeys() []string {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isIdent reports whether name is a valid property name, custom
+// properties (--*) included.
+func isIdent(name string) bool {
+	rest := strings.TrimPrefix(strings.TrimPrefix(name, "-"), "-")
+	if rest == "" {
+		return false
+	}
+	for i, r := range rest {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_', r >= 0x80:
+		case (r >= '0' && r <= '9' || r == '-') && (i > 0 || strings.HasPrefix(name, "--")):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeValue decodes CSS escapes and drops comments and whitespace,
+// lowercased, so obfuscated keywords can be matched.
+func normalizeValue(v string) string {
+	var sb strings.Builder
+	for i := 0; i < len(v); i++ {
+		ch := v[i]
+		switch {
+		case ch == '/' && i+1 < len(v) && v[i+1] == '*':
+			end := strings.Index(v[i+2:], "*/")
+			if end < 0 {
+				i = len(v)
+			} else {
+				i += end + 3
+			}
+		case ch == '\\' && i+1 < len(v):
+			j := i + 1
+			for j < len(v) && j < i+7 && isHex(v[j]) {
+				j++
+			}
+			if j == i+1 {
+				sb.WriteByte(v[j])
+				i = j
+				continue
+			}
+			if n, err := strconv.ParseUint(v[i+1:j], 16, 32); err == nil {
+				sb.WriteRune(rune(n))
+			}
+			if j < len(v) && (v[j] == ' ' || v[j] == '\t') {
+				j++
+			}
+			i = j - 1
+		case ch == ' ' || ch == '\t':
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return strings.ToLower(sb.String())
+}
+
+func isHex(b byte) bool {
+	return b >= '0' && b <= '9' || b >= 'a' && b <= 'f' || b >= 'A' && b <= 'F'
+}
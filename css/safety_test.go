@@ -0,0 +1,164 @@
+package css
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckDeclaration(t *testing.T) {
+	tests := []struct {
+		property, value string
+		ok              bool
+	}{
+		{"color", "red", true},
+		{"color", "#1d4ed8", true},
+		{"--brand", "rgb(29 78 216 / 0.5)", true},
+		{"font-family", `"Inter", sans-serif`, true},
+		{"content", `"a;b{c}"`, true},
+		{"background-image", `url("/img/a.png")`, true},
+		{"grid-template-areas", `"a b" 'c d'`, true},
+		{"content", `"\""`, true},
+
+		{"color", "red; position: fixed", false},
+		{"color", "red}body{color:blue", false},
+		{"color", "red{", false},
+		{"content", `"open`, false},
+		{"content", `'open`, false},
+		{"content", `"\`, false},
+		{"width", "calc(1px", false},
+		{"width", "1px)", false},
+		{"color", "red</style><script>x</script>", false},
+		{"color", "red\nposition:fixed", false},
+		{"color", "red\x00", false},
+		{"width", "expression(alert(1))", false},
+		{"width", `expr\65ssion(alert(1))`, false},
+		{"width", "expr/**/ession(alert(1))", false},
+		{"background", "url(javascript:alert(1))", false},
+		{"background", `url(JaVaScRiPt:alert(1))`, false},
+		{"background", `url(\6a avascript:alert(1))`, false},
+		{"background", "url(vbscript:x)", false},
+		{"-moz-binding", "url(x.xml#xss)", false},
+		{"color", "-moz-binding:url(x)", false},
+		{"color", "@import url(x)", false},
+
+		{"", "red", false},
+		{"col or", "red", false},
+		{"color:red;x", "red", false},
+		{"1color", "red", false},
+		{"-", "red", false},
+	}
+	for _, tt := range tests {
+		err := CheckDeclaration(tt.property, tt.value)
+		if (err == nil) != tt.ok {
+			t.Errorf("CheckDeclaration(%q, %q) = %v, want ok %v", tt.property, tt.value, err, tt.ok)
+		}
+		var unsafe *UnsafeDeclarationError
+		if err != nil && !errors.As(err, &unsafe) {
+			t.Errorf("CheckDeclaration(%q, %q) error is %T, want *UnsafeDeclarationError", tt.property, tt.value, err)
+		}
+	}
+}
+
+func TestInlineDropsUnsafe(t *testing.T) {
+	s := Style{"color": "red", "background": "url(javascript:x)", "width": "1px}"}
+
+	if got, want := s.Inline(), "color:red;"; got != want {
+		t.Errorf("Inline() = %q, want %q", got, want)
+	}
+	if _, err := s.SafeInline(); err == nil {
+		t.Error("SafeInline() accepted unsafe declarations")
+	}
+	if err := s.Check(); err == nil || !strings.Contains(err.Error(), "background") || !strings.Contains(err.Error(), "width") {
+		t.Errorf("Check() = %v, want both unsafe declarations", err)
+	}
+}
+
+func TestStylesheetCheck(t *testing.T) {
+	sheet := NewStylesheet(
+		Rule(".ok", Prop("color", "red")),
+		Media("(min-width: 640px)",
+			Rule(".bad", Prop("color", "red;}body{display:none")),
+		),
+	)
+
+	if got := sheet.String(); strings.Contains(got, "display") {
+		t.Errorf("String() kept an unsafe declaration:\n%s", got)
+	}
+	if err := sheet.Check(); err == nil {
+		t.Error("Check() = nil, want the dropped declaration")
+	}
+	if err := NewStylesheet(Rule(".ok", Prop("color", "red"))).Check(); err != nil {
+		t.Errorf("Check() = %v on a safe stylesheet", err)
+	}
+}
+
+func TestCheckSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		ok       bool
+	}{
+		{".card > h2", true},
+		{`[data-theme="dark"] .btn:hover`, true},
+		{`a[title="{;}"]`, true},
+		{"li:not(.active, :first-child)", true},
+		{"@media (min-width: 640px)", true},
+		{"@layer base, components", true},
+
+		{"", false},
+		{"  ", false},
+		{".a{}body", false},
+		{".a; .b", false},
+		{".a } .b", false},
+		{"</style><script>x</script>", false},
+		{`a[title="</style>"]`, false},
+		{`a[title="open]`, false},
+		{"li:not(.a", false},
+		{".a\n.b", false},
+		{"@media screen{", false},
+	}
+	for _, tt := range tests {
+		if err := CheckSelector(tt.selector); (err == nil) != tt.ok {
+			t.Errorf("CheckSelector(%q) = %v, want ok %v", tt.selector, err, tt.ok)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"plain", `"plain"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`back\slash`, `"back\\slash"`},
+		{"</style>", `"\3c /style>"`},
+		{"line\nbreak\ttab", `"line\a break\9 tab"`},
+		{"café", `"café"`},
+	}
+	for _, tt := range tests {
+		got := Quote(tt.in)
+		if got != tt.want {
+			t.Errorf("Quote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+		if reason := checkStructure(got); reason != "" {
+			t.Errorf("Quote(%q) = %s is unsafe: %s", tt.in, got, reason)
+		}
+	}
+}
+
+func TestStylesheetDropsUnsafeRules(t *testing.T) {
+	sheet := NewStylesheet(
+		Import(`/x.css"); } body { display: none } </style><script>x</script>`),
+		Rule(".ok", Prop("color", "red")),
+		Rule(".a{} body", Prop("display", "none")),
+		Media("screen { body { display: none } } @media print",
+			Rule(".b", Prop("color", "blue")),
+		),
+	)
+
+	got := sheet.String()
+	if want := "@import url(\"/x.css\\\"); } body { display: none } \\3c /style>\\3c script>x\\3c /script>\");\n\n.ok {\n  color: red;\n}\n"; got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+	if err := sheet.Check(); err == nil || !strings.Contains(err.Error(), ".a{} body") || !strings.Contains(err.Error(), "@media screen") {
+		t.Errorf("Check() = %v, want the dropped rule and at-rule", err)
+	}
+}
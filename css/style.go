@@ -1,27 +1,24 @@
 package css
 
 import (
-	"sort"
 	"strings"
 )
 
 type Style map[string]string
 
+// Inline renders s as an inline declaration list. Unsafe declarations
+// (see CheckDeclaration) are dropped; use SafeInline to get an error.
 func (s Style) Inline() string {
 	if len(s) == 0 {
 		return ""
 	}
 
-	// Sort keys for deterministic output
-	keys := make([]string, 0, len(s))
-	for k := range s {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
 	var sb strings.Builder
-	for _, k := range keys {
+	for _, k := range s.sortedKeys() {
 		v := s[k]
+		if CheckDeclaration(k, v) != nil {
+			continue
+		}
 		sb.WriteString(k)
 		sb.WriteString(":")
 		sb.WriteString(v)
@@ -30,3 +27,62 @@ func (s Style) Inline() string {
 
 	return sb.String()
 }
+
+// Merge returns a new Style with the declarations of others applied
+// on top of s, later ones overriding earlier ones per property.
+func (s Style) Merge(others ...Style) Style {
+	merged := Style{}
+	for k, v := range s {
+		merged[k] = v
+	}
+	for _, o := range others {
+		for k, v := range o {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// ParseInline parses an inline declaration list such as
+// "padding:30px; color: red" into a Style. Semicolons inside
+// quotes or parentheses (e.g. url(...)) do not end a declaration,
+// and declarations without a property or value are skipped.
+func ParseInline(inline string) Style {
+	s := Style{}
+	for _, decl := range splitDeclarations(inline) {
+		prop, value, ok := strings.Cut(decl, ":")
+		prop, value = strings.TrimSpace(prop), strings.TrimSpace(value)
+		if !ok || prop == "" || value == "" {
+			continue
+		}
+		s[prop] = value
+	}
+	return s
+}
+
+func splitDeclarations(inline string) []string {
+	var decls []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(inline); i++ {
+		ch := inline[i]
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')' && depth > 0:
+			depth--
+		case ch == ';' && depth == 0:
+			decls = append(decls, inline[start:i])
+			start = i + 1
+		}
+	}
+	return append(decls, inline[start:])
+}
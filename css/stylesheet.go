@@ -0,0 +1,232 @@
+package css
+
+import (
+	"io"
+	"strings"
+)
+
+// -----------------------
+// Stylesheet
+// -----------------------
+
+// Statement is a rule or at-rule inside a Stylesheet.
+type Statement interface {
+	writeStatement(sb *strings.Builder, level int)
+	check() []error
+}
+
+// Stylesheet is an ordered list of rules and at-rules.
+//
+// Example:
+//
+//	css.NewStylesheet(
+//	  css.Rule(".card > h2", css.FontSize(css.Rem(1.25))),
+//	  css.Rule(css.Hover(".btn"), css.BackgroundColor(css.Hex("#1d4ed8"))),
+//	  css.Media("(min-width: 640px)",
+//	    css.Rule(".card", css.Padding(css.Rem(2))),
+//	  ),
+//	)
+type Stylesheet struct {
+	Statements []Statement
+}
+
+// NewStylesheet creates a Stylesheet from the given statements.
+func NewStylesheet(statements ...Statement) *Stylesheet {
+	return &Stylesheet{Statements: statements}
+}
+
+// Add appends statements and returns the stylesheet for chaining.
+func (s *Stylesheet) Add(statements ...Statement) *Stylesheet {
+	s.Statements = append(s.Statements, statements...)
+	return s
+}
+
+// String renders the stylesheet as CSS text, leaving out unsafe
+// declarations, selectors and at-rules (see Check).
+func (s *Stylesheet) String() string {
+	var sb strings.Builder
+	writeStatements(&sb, s.Statements, 0)
+	return sb.String()
+}
+
+// WriteTo writes the rendered stylesheet to w.
+func (s *Stylesheet) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, s.String())
+	return int64(n), err
+}
+
+func writeStatements(sb *strings.Builder, statements []Statement, level int) {
+	first := true
+	for _, st := range statements {
+		if unsafeHeader(st) != nil {
+			continue
+		}
+		if !first {
+			sb.WriteString("\n")
+		}
+		st.writeStatement(sb, level)
+		first = false
+	}
+}
+
+// unsafeHeader checks the selector or prelude of st. Statements whose
+// header fails are dropped whole, as their body cannot be trusted to
+// stay inside them.
+func unsafeHeader(st Statement) error {
+	switch st := st.(type) {
+	case ruleStatement:
+		return CheckSelector(st.selector)
+	case atRuleStatement:
+		return CheckSelector(st.prelude)
+	case simpleStatement:
+		return CheckSelector(string(st))
+	}
+	return nil
+}
+
+func indent(sb *strings.Builder, level int) {
+	sb.WriteString(strings.Repeat("  ", level))
+}
+
+// writeDeclarations writes one "prop: value;" line per declaration,
+// sorted for deterministic output. Unsafe declarations are dropped;
+// Stylesheet.Check reports them.
+func writeDeclarations(sb *strings.Builder, s Style, level int) {
+	for _, k := range s.sortedKeys() {
+		if CheckDeclaration(k, s[k]) != nil {
+			continue
+		}
+		indent(sb, level)
+		sb.WriteString(k)
+		sb.WriteString(": ")
+		sb.WriteString(s[k])
+		sb.WriteString(";\n")
+	}
+}
+
+// -----------------------
+// Rules
+// -----------------------
+
+type ruleStatement struct {
+	selector string
+	style    Style
+}
+
+// Rule styles the elements matched by selector.
+func Rule(selector string, styles ...Style) Statement {
+	return ruleStatement{selector: selector, style: Styles(styles...)}
+}
+
+func (r ruleStatement) writeStatement(sb *strings.Builder, level int) {
+	indent(sb, level)
+	sb.WriteString(r.selector)
+	sb.WriteString(" {\n")
+	writeDeclarations(sb, r.style, level+1)
+	indent(sb, level)
+	sb.WriteString("}\n")
+}
+
+func (r ruleStatement) check() []error {
+	if err := CheckSelector(r.selector); err != nil {
+		return []error{err}
+	}
+	if err := r.style.Check(); err != nil {
+		return []error{err}
+	}
+	return nil
+}
+
+// Pseudo appends a pseudo-class to selector, e.g. Pseudo("a", "visited").
+func Pseudo(selector, class string) string { return selector + ":" + class }
+
+// PseudoElement appends a pseudo-element, e.g. PseudoElement("p", "first-line").
+func PseudoElement(selector, element string) string { return selector + "::" + element }
+
+func Hover(selector string) string        { return Pseudo(selector, "hover") }
+func Focus(selector string) string        { return Pseudo(selector, "focus") }
+func FocusVisible(selector string) string { return Pseudo(selector, "focus-visible") }
+func Active(selector string) string       { return Pseudo(selector, "active") }
+func Disabled(selector string) string     { return Pseudo(selector, "disabled") }
+func FirstChild(selector string) string   { return Pseudo(selector, "first-child") }
+func LastChild(selector string) string    { return Pseudo(selector, "last-child") }
+func Before(selector string) string       { return PseudoElement(selector, "before") }
+func After(selector string) string        { return PseudoElement(selector, "after") }
+
+// -----------------------
+// At-rules
+// -----------------------
+
+type atRuleStatement struct {
+	prelude    string // e.g. "@media (min-width: 640px)"
+	statements []Statement
+}
+
+func (a atRuleStatement) writeStatement(sb *strings.Builder, level int) {
+	indent(sb, level)
+	sb.WriteString(a.prelude)
+	sb.WriteString(" {\n")
+	writeStatements(sb, a.statements, level+1)
+	indent(sb, level)
+	sb.WriteString("}\n")
+}
+
+func (a atRuleStatement) check() []error {
+	if err := CheckSelector(a.prelude); err != nil {
+		return []error{err}
+	}
+	return checkStatements(a.statements)
+}
+
+// Media applies statements when the media query matches.
+func Media(query string, statements ...Statement) Statement {
+	return atRuleStatement{prelude: "@media " + query, statements: statements}
+}
+
+// Supports applies statements when the browser supports the condition,
+// e.g. Supports("(display: grid)", ...).
+func Supports(condition string, statements ...Statement) Statement {
+	return atRuleStatement{prelude: "@supports " + condition, statements: statements}
+}
+
+// Layer puts statements in the named cascade layer.
+func Layer(name string, statements ...Statement) Statement {
+	return atRuleStatement{prelude: "@layer " + name, statements: statements}
+}
+
+// Keyframes defines an animation; use Rule with "from", "to" or
+// percentages as selectors for the frames.
+func Keyframes(name string, frames ...Statement) Statement {
+	return atRuleStatement{prelude: "@keyframes " + name, statements: frames}
+}
+
+type simpleStatement string
+
+func (s simpleStatement) writeStatement(sb *strings.Builder, level int) {
+	indent(sb, level)
+	sb.WriteString(string(s))
+	sb.WriteString(";\n")
+}
+
+func (s simpleStatement) check() []error {
+	if err := CheckSelector(string(s)); err != nil {
+		return []error{err}
+	}
+	return nil
+}
+
+// LayerOrder declares the order of cascade layers, e.g. "@layer base, components;".
+func LayerOrder(names ...string) Statement {
+	return simpleStatement("@layer " + strings.Join(names, ", "))
+}
+
+// Import imports another stylesheet by URL, quoted with Quote.
+func Import(url string) Statement {
+	return simpleStatement("@import url(" + Quote(url) + ")")
+}
+
+// FontFace declares a web font; style holds descriptors such as
+// font-family, src and font-display.
+func FontFace(styles ...Style) Statement {
+	return ruleStatement{selector: "@font-face", style: Styles(styles...)}
+}
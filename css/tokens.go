@@ -0,0 +1,174 @@
+package css
+
+import (
+	"maps"
+	"sort"
+	"strings"
+)
+
+// -----------------------
+// Custom Properties
+// -----------------------
+
+// Var references a custom property, with an optional fallback value.
+//
+// Example:
+//
+//	css.Var("color-primary") // "var(--color-primary)"
+//	css.Var("gap", "1rem")   // "var(--gap, 1rem)"
+func Var(name string, fallback ...string) string {
+	ref := "var(--" + strings.TrimPrefix(name, "--")
+	if len(fallback) > 0 {
+		ref += ", " + strings.Join(fallback, ", ")
+	}
+	return ref + ")"
+}
+
+// ColorVar references a color custom property as a typed color.
+func ColorVar(name string) ColorValue { return ColorValue(Var(name)) }
+
+// LengthVar references a length custom property as a typed length.
+func LengthVar(name string) Length { return Length(Var(name)) }
+
+// -----------------------
+// Design Tokens
+// -----------------------
+
+// Tokens maps custom property names (without the leading "--")
+// to their values.
+type Tokens map[string]string
+
+// Style returns the tokens as custom property declarations.
+func (t Tokens) Style() Style {
+	s := Style{}
+	for name, value := range t {
+		s["--"+strings.TrimPrefix(name, "--")] = value
+	}
+	return s
+}
+
+// Root emits the tokens as ":root { --name: value; ... }".
+func (t Tokens) Root() Statement { return t.Scope(":root") }
+
+// Scope emits the tokens on selector, e.g. `[data-theme="dark"]`,
+// overriding the root values inside that subtree.
+func (t Tokens) Scope(selector string) Statement { return Rule(selector, t.Style()) }
+
+// Merge returns new Tokens with others applied on top of t,
+// e.g. a dark palette over the light one.
+func (t Tokens) Merge(others ...Tokens) Tokens {
+	merged := maps.Clone(t)
+	if merged == nil {
+		merged = Tokens{}
+	}
+	for _, o := range others {
+		maps.Copy(merged, o)
+	}
+	return merged
+}
+
+// DesignTokens groups tokens by kind. Each kind gets its own custom
+// property prefix: --color-*, --spacing-*, --radius-* and --font-*.
+//
+// Example:
+//
+//	tokens := css.DesignTokens{
+//	  Colors:  map[string]css.ColorValue{"primary": css.Hex("#2563eb")},
+//	  Spacing: map[string]css.Length{"4": css.Rem(1)},
+//	}
+//	sheet := css.NewStylesheet(tokens.Tokens().Root())
+//	style := css.Styles(css.Color(tokens.Color("primary")), css.Padding(tokens.Space("4")))
+type DesignTokens struct {
+	Colors  map[string]ColorValue
+	Spacing map[string]Length
+	Radii   map[string]Length
+	Fonts   map[string]string
+}
+
+// Tokens flattens the design tokens into custom properties.
+func (d DesignTokens) Tokens() Tokens {
+	t := Tokens{}
+	for name, v := range d.Colors {
+		t["color-"+name] = string(v)
+	}
+	for name, v := range d.Spacing {
+		t["spacing-"+name] = string(v)
+	}
+	for name, v := range d.Radii {
+		t["radius-"+name] = string(v)
+	}
+	for name, v := range d.Fonts {
+		t["font-"+name] = v
+	}
+	return t
+}
+
+// Color references the named color token.
+func (d DesignTokens) Color(name string) ColorValue { return ColorVar("color-" + name) }
+
+// Space references the named spacing token.
+func (d DesignTokens) Space(name string) Length { return LengthVar("spacing-" + name) }
+
+// Radius references the named radius token.
+func (d DesignTokens) Radius(name string) Length { return LengthVar("radius-" + name) }
+
+// Font references the named font token, for use with FontFamily.
+func (d DesignTokens) Font(name string) string { return Var("font-" + name) }
+
+// -----------------------
+// Color Schemes
+// -----------------------
+
+// ColorSchemeAttr is the attribute that selects a color scheme on the
+// document root, e.g. <html data-color-scheme="dark">.
+const ColorSchemeAttr = "data-color-scheme"
+
+// ColorSchemes emits base tokens on :root and one token override per
+// scheme, so switching to dark mode is a token swap. Each scheme is
+// selected with the ColorSchemeAttr attribute; "light" and "dark" also
+// follow prefers-color-scheme unless the attribute picks a scheme.
+//
+// Example:
+//
+//	sheet := css.NewStylesheet(css.ColorSchemes(light, map[string]css.Tokens{"dark": dark})...)
+func ColorSchemes(base Tokens, schemes map[string]Tokens) []Statement {
+	names := make([]string, 0, len(schemes))
+	for name := range schemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	supported := []string{"light"}
+	for _, name := range names {
+		if name == "dark" {
+			supported = append(supported, name)
+		}
+	}
+
+	statements := []Statement{
+		Rule(":root", base.Style(), Prop("color-scheme", strings.Join(supported, " "))),
+	}
+	for _, name := range names {
+		tokens := schemes[name]
+		if name == "light" || name == "dark" {
+			statements = append(statements, Media(
+				"(prefers-color-scheme: "+name+")",
+				tokens.Scope(":root:not(["+ColorSchemeAttr+"])"),
+			))
+		}
+		statements = append(statements, Rule(
+			"["+ColorSchemeAttr+`="`+name+`"]`,
+			tokens.Style(),
+			colorSchemeProp(name),
+		))
+	}
+	return statements
+}
+
+// colorSchemeProp sets the CSS color-scheme for the built-in schemes.
+func colorSchemeProp(name string) Style {
+	if name == "light" || name == "dark" {
+		return Prop("color-scheme", name)
+	}
+	return Style{}
+}
@@ -0,0 +1,126 @@
+package css
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// -----------------------
+// Lengths
+// -----------------------
+
+// Length is a CSS length or percentage, e.g. "30px" or "50%".
+type Length string
+
+// Auto lets the browser compute the length.
+const Auto Length = "auto"
+
+func num(n float64) string { return strconv.FormatFloat(n, 'f', -1, 64) }
+
+func Px(n float64) Length      { return Length(num(n) + "px") }
+func Rem(n float64) Length     { return Length(num(n) + "rem") }
+func Em(n float64) Length      { return Length(num(n) + "em") }
+func Percent(n float64) Length { return Length(num(n) + "%") }
+func Vh(n float64) Length      { return Length(num(n) + "vh") }
+func Vw(n float64) Length      { return Length(num(n) + "vw") }
+
+// Number is a unitless length, e.g. for line-height or a zero offset.
+func Number(n float64) Length { return Length(num(n)) }
+
+// Calc builds a calc() expression, e.g. Calc("100% - 2rem").
+func Calc(expr string) Length { return Length("calc(" + expr + ")") }
+
+// -----------------------
+// Colors
+// -----------------------
+
+// ColorValue is a CSS color value.
+type ColorValue string
+
+const (
+	Transparent  ColorValue = "transparent"
+	CurrentColor ColorValue = "currentColor"
+)
+
+// Hex returns a hex color, adding the leading "#" if missing.
+func Hex(hex string) ColorValue { return ColorValue("#" + strings.TrimPrefix(hex, "#")) }
+
+// RGB returns an rgb() color from 0-255 channels.
+func RGB(r, g, b uint8) ColorValue { return ColorValue(fmt.Sprintf("rgb(%d %d %d)", r, g, b)) }
+
+// RGBA returns an rgb() color with an alpha channel between 0 and 1.
+func RGBA(r, g, b uint8, a float64) ColorValue {
+	return ColorValue(fmt.Sprintf("rgb(%d %d %d / %s)", r, g, b, num(a)))
+}
+
+// Named returns a named color such as "rebeccapurple".
+func Named(name string) ColorValue { return ColorValue(name) }
+
+// -----------------------
+// Keywords
+// -----------------------
+
+// Keyword is a CSS keyword value for properties like display or position.
+type Keyword string
+
+const (
+	None Keyword = "none"
+
+	// display
+	Block       Keyword = "block"
+	Inline      Keyword = "inline"
+	InlineBlock Keyword = "inline-block"
+	Flex        Keyword = "flex"
+	InlineFlex  Keyword = "inline-flex"
+	Grid        Keyword = "grid"
+	InlineGrid  Keyword = "inline-grid"
+	Contents    Keyword = "contents"
+
+	// position
+	Static   Keyword = "static"
+	Relative Keyword = "relative"
+	Absolute Keyword = "absolute"
+	Fixed    Keyword = "fixed"
+	Sticky   Keyword = "sticky"
+
+	// overflow
+	Visible Keyword = "visible"
+	Hidden  Keyword = "hidden"
+	Scroll  Keyword = "scroll"
+	Clip    Keyword = "clip"
+
+	// border-style
+	Solid  Keyword = "solid"
+	Dashed Keyword = "dashed"
+	Dotted Keyword = "dotted"
+	Double Keyword = "double"
+
+	// text-align, justify-content, align-items
+	TextLeft     Keyword = "left"
+	TextRight    Keyword = "right"
+	Center       Keyword = "center"
+	Justify      Keyword = "justify"
+	Start        Keyword = "start"
+	End          Keyword = "end"
+	FlexStart    Keyword = "flex-start"
+	FlexEnd      Keyword = "flex-end"
+	SpaceBetween Keyword = "space-between"
+	SpaceAround  Keyword = "space-around"
+	SpaceEvenly  Keyword = "space-evenly"
+	Stretch      Keyword = "stretch"
+	Baseline     Keyword = "baseline"
+
+	// flex-direction, flex-wrap
+	Row           Keyword = "row"
+	RowReverse    Keyword = "row-reverse"
+	Column        Keyword = "column"
+	ColumnReverse Keyword = "column-reverse"
+	Wrap          Keyword = "wrap"
+	NoWrap        Keyword = "nowrap"
+
+	// font-weight, font-style
+	Normal Keyword = "normal"
+	Bold   Keyword = "bold"
+	Italic Keyword = "italic"
+)
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/GopherGhaznix/Wave/html"
+)
+
+// validator logs content-model problems of every page under "wave dev"
+var validator = html.NewValidator(html.LogViolation)
+
+// pageContext returns the context a request's page renders with. Each
+// render gets its own Validator fork, as validation tracks the element
+// being rendered.
+func pageContext(r *http.Request) context.Context {
+	c := html.WithTheme(r.Context(), html.NewDefaultTheme())
+	if os.Getenv(html.LiveReloadEnv) != "" {
+		c = html.WithValidator(c, validator.Fork())
+	}
+	return html.DevContext(c)
+}
+
+// -----------------------
+// Pages
+// -----------------------
+func home(w http.ResponseWriter, r *http.Request) {
+	c := pageContext(r)
+
+	page := html.Html(c, html.AttrLang("en"),
+		html.Head(c, nil,
+			html.Title(c, nil, html.Text("Wave server")),
+		),
+		html.Body(c, nil,
+			html.H1(c, nil, html.Text("Hello Wave 🌊")),
+			html.P(c, nil, html.Text("Edit this file while wave dev is running.")),
+		),
+	)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, "<!DOCTYPE html>\n"+page())
+}
+
+// dashboard streams the page shell at once and each widget when ready.
+func dashboard(w http.ResponseWriter, r *http.Request) {
+	stream := html.NewStream(w)
+	c := html.WithStream(pageContext(r), stream)
+
+	widget := func(name string, delay time.Duration) html.Node {
+		return html.Suspense(c, html.Text("Loading "+name+"…"), func(c context.Context) (html.Node, error) {
+			select {
+			case <-time.After(delay):
+			case <-c.Done():
+				return nil, c.Err()
+			}
+			return html.P(c, nil, html.Text(name+" loaded after "+delay.String())), nil
+		})
+	}
+
+	page := html.Html(c, html.AttrLang("en"),
+		html.Head(c, nil,
+			html.Title(c, nil, html.Text("Wave dashboard")),
+		),
+		html.Body(c, nil,
+			html.H1(c, nil, html.Text("Dashboard")),
+			widget("Orders", 1500*time.Millisecond),
+			widget("Revenue", 500*time.Millisecond),
+			widget("Visitors", time.Second),
+		),
+	)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintln(w, "<!DOCTYPE html>")
+	if err := stream.Render(c, page); err != nil {
+		log.Print(err)
+	}
+}
+
+func main() {
+	addr := "localhost:" + cmp.Or(os.Getenv("PORT"), "8080")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", home)
+	mux.HandleFunc("GET /dashboard", dashboard)
+
+	log.Printf("listening on http://%s", addr)
+	log.Fatal(http.ListenAndServe(addr, html.NonceMiddleware(nil, mux)))
+}
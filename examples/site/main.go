@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+
+	"github.com/GopherGhaznix/Wave/html"
+	"github.com/GopherGhaznix/Wave/site"
+)
+
+// -----------------------
+// Pages
+// -----------------------
+func layout(c context.Context, title string, content ...html.Node) html.Node {
+	return html.Html(c, html.AttrLang("en"),
+		html.Head(c, nil,
+			html.Meta(c, html.Attrs{"charset": "utf-8"}),
+			html.Title(c, nil, html.Text(title)),
+		),
+		html.Body(c, nil,
+			html.Nav(c, nil,
+				html.A(c, html.AttrHref("/"), html.Text("Home")),
+				html.A(c, html.AttrHref("/about"), html.Text("About")),
+			),
+			html.Main(c, nil, content...),
+		),
+	)
+}
+
+func home(c context.Context) html.Node {
+	return layout(c, "Wave",
+		html.H1(c, nil, html.Text("Hello Wave 🌊")),
+		html.P(c, nil, html.Text("This page was built by wave build.")),
+	)
+}
+
+func about(c context.Context) html.Node {
+	return layout(c, "About Wave",
+		html.H1(c, nil, html.Text("About")),
+		html.P(c, nil, html.Text("Fluid pages, powered by Go at the core.")),
+	)
+}
+
+func main() {
+	site.Main(site.Config{
+		BaseURL: "https://wave.example",
+		Context: html.WithTheme(context.Background(), html.NewDefaultTheme()),
+		Routes: []site.Route{
+			{Path: "/", Page: home},
+			{Path: "/about", Page: about},
+		},
+	})
+}
@@ -27,11 +27,11 @@ func main() {
 			// Attributes
 			html.AttrID("root"),
 			html.AttrClass("container bg-blue-200"),
-			html.AttrStyle(css.Style{
-				"padding": "30px",
-				"height":  "90vh",
-				"border":  "1px solid #eee",
-			}),
+			html.AttrStyle(css.Styles(
+				css.Padding(css.Px(30)),
+				css.Height(css.Vh(90)),
+				css.Border(css.Px(1), css.Solid, css.Hex("#eee")),
+			)),
 		),
 		// Childerns
 		html.H1(c,
@@ -69,6 +69,12 @@ func main() {
 			// Childerns
 			html.Text("Click Me"),
 		),
+		html.Button(c,
+			// Attributes
+			html.Attributes(html.Variant("danger")),
+			// Childerns
+			html.Text("Delete"),
+		),
 	)
 
 	// render final HTML
@@ -40,8 +40,8 @@ func MyCustomTailwindTheme() context.Context {
 				// ----------------
 				// Buttons
 				// ----------------
-				"button": html.AttrClass("inline-flex items-center px-4 py-2 rounded-lg bg-blue-600 text-white font-medium hover:bg-blue-700 disabled:opacity-50 disabled:cursor-not-allowed transition-colors"),
-				"submit": html.AttrClass("inline-flex items-center px-4 py-2 rounded-lg bg-green-600 text-white font-medium hover:bg-green-700 transition-colors"),
+				"button":              html.AttrClass("inline-flex items-center px-4 py-2 rounded-lg bg-blue-600 text-white font-medium hover:bg-blue-700 disabled:opacity-50 disabled:cursor-not-allowed transition-colors"),
+				"button[type=submit]": html.AttrClass("inline-flex items-center px-4 py-2 rounded-lg bg-green-600 text-white font-medium hover:bg-green-700 transition-colors"),
 
 				// ----------------
 				// Forms
@@ -96,6 +96,11 @@ func MyCustomTailwindTheme() context.Context {
 				"hr":         html.AttrClass("my-6 border-gray-300"),
 				"pre":        html.AttrClass("bg-gray-900 text-gray-100 p-4 rounded-lg overflow-x-auto font-mono text-sm"),
 			},
-		),
+		).WithVariant("danger", map[string]html.Attrs{
+			// ----------------
+			// Variants
+			// ----------------
+			"button": html.AttrClass("bg-red-600 hover:bg-red-700"),
+		}),
 	)
 }
@@ -1,7 +1,10 @@
 package html
 
 import (
+	"errors"
+	"fmt"
 	"maps"
+	"slices"
 
 	"github.com/GopherGhaznix/Wave/css"
 )
@@ -12,21 +15,61 @@ import (
 type Attrs map[string]string
 
 // Attributes returns a new Attrs map that combines all key-value pairs from the provided maps.
+// Later maps win, except for attributes that combine:
+// - class: concatenated, dropping duplicates.
+// - style: merged per CSS declaration.
+// - rel, aria-* id lists and other token lists: union of tokens.
 func Attributes(mapsList ...Attrs) Attrs {
 	merged := Attrs{}
 	for _, m := range mapsList {
-		maps.Copy(merged, m)
+		merged = mergeAttrsWith(combineAttrMerger, merged, m)
 	}
 	return merged
 }
 
+// AttrConflictError reports a scalar attribute set to two different values.
+type AttrConflictError struct {
+	Name   string
+	First  string
+	Second string
+}
+
+func (e *AttrConflictError) Error() string {
+	return fmt.Sprintf("wave: conflicting values for attribute %q: %q and %q", e.Name, e.First, e.Second)
+}
+
+// StrictAttributes combines maps like Attributes, but also returns an
+// error (joined *AttrConflictError values) for every scalar attribute
+// that is given two different values instead of silently keeping the last.
+func StrictAttributes(mapsList ...Attrs) (Attrs, error) {
+	var errs []error
+	seen := Attrs{}
+	for _, m := range mapsList {
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			prev, ok := seen[k]
+			if ok && prev != "" && m[k] != "" && prev != m[k] && combineAttrMerger(k) == nil {
+				errs = append(errs, &AttrConflictError{Name: k, First: prev, Second: m[k]})
+			}
+			seen[k] = m[k]
+		}
+	}
+	return Attributes(mapsList...), errors.Join(errs...)
+}
+
+// combineAttrMerger returns how Attributes combines the named attribute.
+func combineAttrMerger(name string) AttrMerger {
+	if name == "class" {
+		return AppendClassMerge
+	}
+	return defaultAttrMergers[name]
+}
+
 // -----------------------
 // Attribute Wrappers
 // -----------------------
 
 // Common form attributes
 func AttrAccept(value string) Attrs       { return Attrs{"accept": value} }
-func AttrHref(value string) Attrs         { return Attrs{"href": value} }
 func AttrAutocomplete(value string) Attrs { return Attrs{"autocomplete": value} }
 func AttrCapture(value string) Attrs      { return Attrs{"capture": value} }
 func AttrDisabled() Attrs                 { return Attrs{"disabled": "disabled"} }
@@ -70,9 +113,20 @@ func AttrTypeSubmit() Attrs        { return Attrs{"type": "submit"} }
 func AttrTypeReset() Attrs         { return Attrs{"type": "reset"} }
 func AttrTypeImage() Attrs         { return Attrs{"type": "image"} }
 
+// URL attributes (checked against the URLPolicy in context when rendered)
+func AttrHref(value string) Attrs       { return Attrs{"href": value} }
+func AttrSrc(value string) Attrs        { return Attrs{"src": value} }
+func AttrSrcset(value string) Attrs     { return Attrs{"srcset": value} }
+func AttrAction(value string) Attrs     { return Attrs{"action": value} }
+func AttrFormAction(value string) Attrs { return Attrs{"formaction": value} }
+func AttrPoster(value string) Attrs     { return Attrs{"poster": value} }
+func AttrCite(value string) Attrs       { return Attrs{"cite": value} }
+
 // Media/timing
 func AttrElementTiming(value string) Attrs { return Attrs{"elementtiming": value} }
 func AttrCrossOrigin(value string) Attrs   { return Attrs{"crossorigin": value} }
+func AttrIntegrity(value string) Attrs     { return Attrs{"integrity": value} }
+func AttrAs(value string) Attrs            { return Attrs{"as": value} }
 
 // Global attributes
 func AttrAccessKey(value string) Attrs             { return Attrs{"accesskey": value} }
@@ -110,7 +164,29 @@ func AttrVirtualKeyboardPolicy(value string) Attrs { return Attrs{"virtualkeyboa
 func AttrWritingSuggestions(value string) Attrs    { return Attrs{"writingsuggestions": value} }
 
 // AttrStyle converts a style map into an inline "style" attribute.
-func AttrStyle(styles css.Style) Attrs { return Attrs{"style": styles.Inline()} }
+// Unsafe declarations are dropped and reported to the ErrorHandler in
+// context when the element renders; see AttrStyleChecked.
+func AttrStyle(styles css.Style) Attrs {
+	attrs := Attrs{"style": styles.Inline()}
+	if err := styles.Check(); err != nil {
+		attrs[unsafeStyleAttr] = err.Error()
+	}
+	return attrs
+}
+
+// unsafeStyleAttr carries the declarations AttrStyle dropped to the
+// element, which reports them with its context. It is never rendered.
+const unsafeStyleAttr = "wave-unsafe-style"
+
+// AttrStyleChecked is AttrStyle for styles built from user data: it
+// returns an error instead of dropping unsafe declarations.
+func AttrStyleChecked(styles css.Style) (Attrs, error) {
+	inline, err := styles.SafeInline()
+	if err != nil {
+		return nil, err
+	}
+	return Attrs{"style": inline}, nil
+}
 
 // <script> specific
 func AttrImportMap(value string) Attrs        { return Attrs{"importmap": value} }
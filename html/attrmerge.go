@@ -0,0 +1,75 @@
+package html
+
+import (
+	"strings"
+
+	"github.com/GopherGhaznix/Wave/css"
+)
+
+// AttrMerger combines a theme's value for an attribute with the value
+// given by the user. A nil AttrMerger means the user value overrides,
+// and so does an explicitly empty user value, which clears the theme's.
+type AttrMerger func(themeValue, userValue string) string
+
+// StyleAttrMerge merges inline styles per CSS declaration, so a user
+// style only overrides the theme properties it sets itself.
+func StyleAttrMerge(themeValue, userValue string) string {
+	return css.ParseInline(themeValue).Merge(css.ParseInline(userValue)).Inline()
+}
+
+// TokenListAttrMerge takes the union of two space-separated token
+// lists, theme tokens first, as used by rel or aria-describedby.
+func TokenListAttrMerge(themeValue, userValue string) string {
+	return AppendClassMerge(themeValue, userValue)
+}
+
+// defaultAttrMergers lists the attributes that are not simply overridden.
+// class is handled by the theme's ClassMerge.
+var defaultAttrMergers = map[string]AttrMerger{
+	"style":            StyleAttrMerge,
+	"rel":              TokenListAttrMerge,
+	"headers":          TokenListAttrMerge,
+	"itemprop":         TokenListAttrMerge,
+	"itemref":          TokenListAttrMerge,
+	"part":             TokenListAttrMerge,
+	"ping":             TokenListAttrMerge,
+	"sandbox":          TokenListAttrMerge,
+	"aria-controls":    TokenListAttrMerge,
+	"aria-describedby": TokenListAttrMerge,
+	"aria-details":     TokenListAttrMerge,
+	"aria-flowto":      TokenListAttrMerge,
+	"aria-labelledby":  TokenListAttrMerge,
+	"aria-owns":        TokenListAttrMerge,
+}
+
+// defaultAttrMerger returns how name is merged without a theme.
+func defaultAttrMerger(name string) AttrMerger {
+	if name == "class" {
+		return TailwindClassMerge
+	}
+	return defaultAttrMergers[name]
+}
+
+// mergeAttrsWith merges theme attrs with user attrs, combining each
+// attribute with the AttrMerger returned by mergerFor, or letting the
+// user value override when there is none or the user value is empty.
+func mergeAttrsWith(mergerFor func(name string) AttrMerger, themeAttrs, userAttrs Attrs) Attrs {
+	result := Attrs{}
+
+	// Copy theme defaults first
+	for k, v := range themeAttrs {
+		result[k] = v
+	}
+
+	// Apply user values
+	for k, v := range userAttrs {
+		themeValue := strings.TrimSpace(themeAttrs[k])
+		if merge := mergerFor(k); merge != nil && themeValue != "" && v != "" {
+			result[k] = merge(themeValue, v)
+		} else {
+			result[k] = v
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,215 @@
+package html
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// unexported key type ensures uniqueness
+type cacheContextKey struct{}
+
+// Cache stores rendered output for Cached nodes. Implementations must be
+// safe for concurrent use; a ttl <= 0 means no expiry.
+type Cache interface {
+	Get(key string) (string, bool)
+	Set(key, value string, ttl time.Duration, tags []string)
+	Delete(key string)
+	InvalidateTag(tag string)
+}
+
+// WithCache returns a new context carrying the render cache. Give each
+// app, or test, its own cache so entries are not shared between them.
+func WithCache(ctx context.Context, cache Cache) context.Context {
+	return context.WithValue(ctx, cacheContextKey{}, cache)
+}
+
+// CacheFromContext retrieves the render cache from context, if set.
+func CacheFromContext(ctx context.Context) (Cache, bool) {
+	cache, ok := ctx.Value(cacheContextKey{}).(Cache)
+	return cache, ok && cache != nil
+}
+
+// Sentinels stand in for per-request values in cached output and are
+// replaced on every render.
+const (
+	nonceSentinel = "\x00wave-nonce\x00"
+	idSentinel    = "\x00wave-id\x00"
+)
+
+// Cached renders the node built by build once and serves the stored
+// output from the Cache in context until ttl passes or the key, or one
+// of the tags, is invalidated. Without a Cache in context, build runs
+// on every render.
+//
+// The subtree is built with a context that makes its output reusable:
+//   - auto ids are sequential under a prefix drawn from the IDGenerator
+//     in context on every use, e.g. "wave-7-1", so the same fragment
+//     rendered twice on a page gets distinct ids;
+//   - the CSP nonce is swapped for the current request's on every hit;
+//   - Suspense nodes render in place instead of streaming.
+//
+// Everything else the output depends on, like the theme or the user,
+// belongs in the key. Hits do not render the subtree, so a
+// ClassCollector only sees its classes when the entry is built.
+//
+// Example:
+//
+//	c = html.WithCache(c, cache) // e.g. html.NewLRUCache(1024), once per app
+//
+//	html.Cached(c, "footer", time.Hour, func(c context.Context) html.Node {
+//		return Footer(c, links)
+//	}, "layout")
+//
+//	cache.InvalidateTag("layout")
+func Cached(c context.Context, key string, ttl time.Duration, build func(context.Context) Node, tags ...string) Node {
+	return func() string {
+		cache, ok := CacheFromContext(c)
+		var out string
+		if ok {
+			out, ok = cache.Get(key)
+		}
+		if !ok {
+			bc := WithIDGenerator(c, SequentialIDs(idSentinel))
+			bc = WithNonce(bc, nonceSentinel)
+			bc = WithStream(bc, nil)
+			if node := build(bc); node != nil {
+				out = node()
+			}
+			if cache, ok := CacheFromContext(c); ok {
+				cache.Set(key, out, ttl, tags)
+			}
+		}
+
+		prefix, ok := generateID(c, "cached")
+		if !ok {
+			prefix = "wave-" + idSafe(key)
+		}
+		nonce := ""
+		if n, ok := NonceFromContext(c); ok {
+			nonce = ` nonce="` + attrEscaper.Replace(n) + `"`
+		}
+		return strings.NewReplacer(
+			idSentinel, attrEscaper.Replace(prefix),
+			` nonce="`+nonceSentinel+`"`, nonce,
+		).Replace(out)
+	}
+}
+
+// idSafe maps a cache key to characters that are safe in an id.
+func idSafe(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' {
+			return r
+		}
+		return '-'
+	}, key)
+}
+
+// -----------------------
+// LRU Cache
+// -----------------------
+
+// LRUCache is an in-memory Cache evicting the least recently used entry
+// once it holds more than its capacity.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is most recently used
+	entries  map[string]*list.Element
+	tags     map[string]map[string]bool // tag -> keys
+}
+
+type lruEntry struct {
+	key     string
+	value   string
+	expires time.Time // zero means never
+	tags    []string
+}
+
+// NewLRUCache returns an LRUCache holding at most capacity entries.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		entries:  map[string]*list.Element{},
+		tags:     map[string]map[string]bool{},
+	}
+}
+
+func (l *LRUCache) Get(key string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, ok := l.entries[key]
+	if !ok {
+		return "", false
+	}
+	e := el.Value.(*lruEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		l.remove(el)
+		return "", false
+	}
+	l.order.MoveToFront(el)
+	return e.value, true
+}
+
+func (l *LRUCache) Set(key, value string, ttl time.Duration, tags []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.entries[key]; ok {
+		l.remove(el)
+	}
+
+	e := &lruEntry{key: key, value: value, tags: tags}
+	if ttl > 0 {
+		e.expires = time.Now().Add(ttl)
+	}
+	l.entries[key] = l.order.PushFront(e)
+	for _, tag := range tags {
+		if l.tags[tag] == nil {
+			l.tags[tag] = map[string]bool{}
+		}
+		l.tags[tag][key] = true
+	}
+
+	for l.order.Len() > l.capacity {
+		l.remove(l.order.Back())
+	}
+}
+
+func (l *LRUCache) Delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.entries[key]; ok {
+		l.remove(el)
+	}
+}
+
+func (l *LRUCache) InvalidateTag(tag string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key := range l.tags[tag] {
+		l.remove(l.entries[key])
+	}
+}
+
+// Len returns the number of entries, expired ones included.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+// remove unlinks an entry from the list, the index and its tags.
+func (l *LRUCache) remove(el *list.Element) {
+	e := l.order.Remove(el).(*lruEntry)
+	delete(l.entries, e.key)
+	for _, tag := range e.tags {
+		delete(l.tags[tag], e.key)
+		if len(l.tags[tag]) == 0 {
+			delete(l.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package html
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2)
+	cache.Set("a", "A", 0, nil)
+	cache.Set("b", "B", 0, nil)
+	cache.Get("a") // a is now the most recently used
+	cache.Set("c", "C", 0, nil)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("least recently used entry b was kept")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("entry %s was evicted", key)
+		}
+	}
+	if n := cache.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+}
+
+func TestLRUCacheExpiryAndTags(t *testing.T) {
+	cache := NewLRUCache(10)
+	cache.Set("short", "x", time.Millisecond, nil)
+	cache.Set("nav", "x", 0, []string{"layout"})
+	cache.Set("footer", "x", 0, []string{"layout", "links"})
+	cache.Set("post", "x", 0, []string{"posts"})
+
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := cache.Get("short"); ok {
+		t.Error("expired entry was served")
+	}
+
+	cache.InvalidateTag("layout")
+	for key, want := range map[string]bool{"nav": false, "footer": false, "post": true} {
+		if _, ok := cache.Get(key); ok != want {
+			t.Errorf("after InvalidateTag, Get(%q) ok = %v, want %v", key, ok, want)
+		}
+	}
+
+	// Re-setting a key drops its old tags
+	cache.Set("post", "y", 0, nil)
+	cache.InvalidateTag("posts")
+	if v, ok := cache.Get("post"); !ok || v != "y" {
+		t.Errorf("Get(post) = %q, %v after invalidating a tag it no longer has", v, ok)
+	}
+}
+
+func TestLRUCacheConcurrent(t *testing.T) {
+	cache := NewLRUCache(16)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 200 {
+				key := strconv.Itoa((i + j) % 32)
+				cache.Set(key, key, time.Minute, []string{"t" + strconv.Itoa(j%3)})
+				if v, ok := cache.Get(key); ok && v != key {
+					t.Errorf("Get(%q) = %q", key, v)
+				}
+				if j%50 == 0 {
+					cache.InvalidateTag("t0")
+					cache.Delete(key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := cache.Len(); n > 16 {
+		t.Errorf("Len() = %d, want at most the capacity", n)
+	}
+}
+
+func TestCachedNonce(t *testing.T) {
+	base := WithCache(context.Background(), NewLRUCache(10))
+	builds := 0
+	page := func(c context.Context) string {
+		return Cached(c, "widget", 0, func(c context.Context) Node {
+			builds++
+			return Script(c, nil, Text("init()"))
+		})()
+	}
+
+	first := page(WithNonce(base, "n1"))
+	second := page(WithNonce(base, "n2"))
+	third := page(base)
+
+	if builds != 1 {
+		t.Errorf("built %d times, want 1", builds)
+	}
+	if !strings.Contains(first, `nonce="n1"`) {
+		t.Errorf("first render lacks its nonce: %s", first)
+	}
+	if !strings.Contains(second, `nonce="n2"`) || strings.Contains(second, "n1") {
+		t.Errorf("hit kept the first nonce: %s", second)
+	}
+	if strings.Contains(third, "nonce") || strings.Contains(third, "\x00") {
+		t.Errorf("hit without a nonce: %q", third)
+	}
+}
+
+func TestCachedIDs(t *testing.T) {
+	cache := NewLRUCache(10)
+	render := func() string {
+		c := WithCache(WithIDGenerator(context.Background(), SequentialIDs("wave")), cache)
+		field := func() Node {
+			return Cached(c, "field", 0, func(c context.Context) Node {
+				return Div(c, nil, Label(c, nil), Input(c, nil))
+			})
+		}
+		return Main(c, nil, field(), field())()
+	}
+
+	first := render()
+	for _, id := range []string{`id="wave-2-1"`, `id="wave-2-3"`, `id="wave-3-1"`, `id="wave-3-3"`} {
+		if strings.Count(first, id) != 1 {
+			t.Errorf("want %s exactly once in:\n%s", id, first)
+		}
+	}
+	if second := render(); second != first {
+		t.Errorf("cached render changed:\n%s\nthen\n%s", first, second)
+	}
+}
+
+func TestCachedWithoutCache(t *testing.T) {
+	c := context.Background()
+	builds := 0
+	node := Cached(c, "k", time.Hour, func(c context.Context) Node {
+		builds++
+		return Text("x")
+	})
+	node()
+	node()
+	if builds != 2 {
+		t.Errorf("built %d times without a cache, want 2", builds)
+	}
+}
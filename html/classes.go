@@ -0,0 +1,118 @@
+package html
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/GopherGhaznix/Wave/css"
+)
+
+// unexported key type ensures uniqueness
+type classCollectorContextKey struct{}
+
+// ClassCollector records every class rendered by Element, after theme
+// merging, so a build can emit a Tailwind safelist instead of loading
+// the Tailwind browser script at runtime.
+//
+// Example:
+//
+//	col := NewClassCollector()
+//	page := Page(WithClassCollector(ctx, col))
+//	page()
+//	os.WriteFile("safelist.css", []byte(col.TailwindSource()), 0o644)
+//
+// Only elements that actually render are recorded: Cached hits replay
+// stored output, so classes inside a Cached node are missed unless the
+// cache is empty (e.g. use a fresh Cache for the collecting render), and
+// the same goes for branches a page does not take. AddTheme records a
+// theme's classes up front. The zero value is ready to use.
+type ClassCollector struct {
+	mu      sync.Mutex
+	classes map[string]bool
+}
+
+// NewClassCollector returns an empty ClassCollector.
+func NewClassCollector() *ClassCollector {
+	return &ClassCollector{classes: map[string]bool{}}
+}
+
+// WithClassCollector returns a new context carrying the given collector.
+func WithClassCollector(ctx context.Context, col *ClassCollector) context.Context {
+	return context.WithValue(ctx, classCollectorContextKey{}, col)
+}
+
+// ClassCollectorFromContext retrieves the class collector from context, if set.
+func ClassCollectorFromContext(ctx context.Context) (*ClassCollector, bool) {
+	col, ok := ctx.Value(classCollectorContextKey{}).(*ClassCollector)
+	return col, ok
+}
+
+// Add records every class in a space-separated class list.
+func (col *ClassCollector) Add(classList string) {
+	col.mu.Lock()
+	defer col.mu.Unlock()
+	if col.classes == nil {
+		col.classes = map[string]bool{}
+	}
+	for _, c := range strings.Fields(classList) {
+		col.classes[c] = true
+	}
+}
+
+// AddTheme records the classes of every rule in theme, including its
+// color schemes as they render: prefixed with the scheme name
+// ("dark:bg-gray-900"), or bare under SchemeServer.
+func (col *ClassCollector) AddTheme(theme *Theme) {
+	if theme == nil {
+		return
+	}
+	for _, attrs := range theme.Elements {
+		col.Add(attrs["class"])
+	}
+	for _, rules := range theme.Variants {
+		for _, attrs := range rules {
+			col.Add(attrs["class"])
+		}
+	}
+	for name, rules := range theme.Schemes {
+		for _, attrs := range rules {
+			if theme.SchemeStrategy != SchemeServer {
+				col.Add(prefixClasses(name, attrs["class"]))
+			} else {
+				col.Add(attrs["class"])
+			}
+		}
+	}
+}
+
+// Classes returns the recorded classes, sorted.
+func (col *ClassCollector) Classes() []string {
+	col.mu.Lock()
+	defer col.mu.Unlock()
+	classes := make([]string, 0, len(col.classes))
+	for c := range col.classes {
+		classes = append(classes, c)
+	}
+	slices.Sort(classes)
+	return classes
+}
+
+// Safelist returns the recorded classes one per line, as accepted by
+// the Tailwind v3 safelist or any tool reading plain class lists.
+func (col *ClassCollector) Safelist() string {
+	classes := col.Classes()
+	if len(classes) == 0 {
+		return ""
+	}
+	return strings.Join(classes, "\n") + "\n"
+}
+
+// TailwindSource returns a Tailwind v4 "@source inline(...)" directive
+// listing the recorded classes, to import from the CSS entry point. The
+// list is a CSS string, so arbitrary values keep their quotes and
+// backslashes.
+func (col *ClassCollector) TailwindSource() string {
+	return "@source inline(" + css.Quote(strings.Join(col.Classes(), " ")) + ");\n"
+}
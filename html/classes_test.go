@@ -0,0 +1,31 @@
+package html
+
+import "testing"
+
+func TestTailwindSource(t *testing.T) {
+	var col ClassCollector // the zero value is usable
+	col.Add(`p-4 content-['a"b'] bg-[url('/x\y.png')]`)
+	col.AddTheme(nil)
+
+	want := `@source inline("bg-[url('/x\\y.png')] content-['a\"b'] p-4");` + "\n"
+	if got := col.TailwindSource(); got != want {
+		t.Errorf("TailwindSource() = %s, want %s", got, want)
+	}
+}
+
+func TestAddThemeSchemes(t *testing.T) {
+	theme := NewTheme(map[string]Attrs{"p": AttrClass("text-gray-800")}).
+		WithScheme("dark", map[string]Attrs{"p": AttrClass("text-gray-200")})
+
+	col := NewClassCollector()
+	col.AddTheme(theme)
+	if got, want := col.Safelist(), "dark:text-gray-200\ntext-gray-800\n"; got != want {
+		t.Errorf("Safelist() = %q, want %q", got, want)
+	}
+
+	col = NewClassCollector()
+	col.AddTheme(theme.WithSchemeStrategy(SchemeServer))
+	if got, want := col.Safelist(), "text-gray-200\ntext-gray-800\n"; got != want {
+		t.Errorf("Safelist() under SchemeServer = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package html
+
+import (
+	"strings"
+)
+
+// ClassMerger combines a theme's classes with the classes given
+// by the user for the same element. A Theme uses TailwindClassMerge
+// unless its ClassMerge field says otherwise.
+type ClassMerger func(themeClass, userClass string) string
+
+// TailwindClassMerge replaces theme classes that set the same CSS
+// property under the same variants as a user class (see mergeTailwind).
+func TailwindClassMerge(themeClass, userClass string) string {
+	return mergeTailwind(themeClass, userClass)
+}
+
+// AppendClassMerge keeps every class, theme first, dropping exact
+// duplicates. It suits Bootstrap and BEM, where "btn btn-primary"
+// are meant to be used together.
+func AppendClassMerge(themeClass, userClass string) string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, c := range strings.Fields(themeClass + " " + userClass) {
+		if !seen[c] {
+			seen[c] = true
+			merged = append(merged, c)
+		}
+	}
+	return strings.Join(merged, " ")
+}
+
+// ReplaceClassMerge uses the user classes verbatim when given,
+// dropping the theme classes altogether.
+func ReplaceClassMerge(themeClass, userClass string) string {
+	if strings.TrimSpace(userClass) != "" {
+		return userClass
+	}
+	return themeClass
+}
@@ -3,10 +3,12 @@ package html
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
 
-	"github.com/google/uuid"
+	"github.com/GopherGhaznix/Wave/css"
 )
 
 // -----------------------
@@ -24,80 +26,38 @@ func indentBlock(content string, level int) string {
 	prefix := strings.Repeat("  ", level)
 	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
 	for i, line := range lines {
-		lines[i] = prefix + line
+		if line != "" {
+			lines[i] = prefix + line
+		}
 	}
 	return strings.Join(lines, "\n")
 }
 
 // mergeAttrs merges theme attrs with user attrs.
-// - Non-class attributes: user overrides theme.
-// - class attribute: merge intelligently, replacing conflicts.
+// - class: Tailwind-aware merge, replacing conflicts.
+// - style, rel, aria-* id lists: merged (see defaultAttrMergers).
+// - Other attributes: user overrides theme.
 func mergeAttrs(themeAttrs, userAttrs Attrs) Attrs {
-	result := Attrs{}
-
-	// Copy theme defaults first
-	for k, v := range themeAttrs {
-		result[k] = v
-	}
-
-	// Apply user overrides
-	for k, v := range userAttrs {
-		if k == "class" {
-			result[k] = mergeClasses(themeAttrs["class"], v)
-		} else {
-			result[k] = v
-		}
-	}
-
-	return result
+	return mergeAttrsWith(defaultAttrMerger, themeAttrs, userAttrs)
 }
 
-// mergeClasses merges theme classes with user classes.
-// User classes replace theme classes that share the same "prefix" (e.g. bg-*, text-*, font-*).
-func mergeClasses(themeClass, userClass string) string {
-	themeList := strings.Fields(themeClass)
-	userList := strings.Fields(userClass)
-
-	// Build a set of user class "prefixes"
-	// Example: "bg-red-500" → prefix "bg-"
-	userPrefixes := map[string]string{}
-	for _, uc := range userList {
-		prefix := classPrefix(uc)
-		userPrefixes[prefix] = uc
-	}
-
-	merged := []string{}
-
-	// Keep theme classes unless overridden
-	for _, tc := range themeList {
-		prefix := classPrefix(tc)
-		if uc, ok := userPrefixes[prefix]; ok {
-			merged = append(merged, uc) // user override
-			delete(userPrefixes, prefix)
-		} else {
-			merged = append(merged, tc) // keep theme default
-		}
-	}
+// attrEscaper escapes attribute values so they cannot close the
+// surrounding double quotes or the tag.
+var attrEscaper = strings.NewReplacer(
+	`&`, "&amp;",
+	`"`, "&quot;",
+	`<`, "&lt;",
+	`>`, "&gt;",
+)
 
-	// Add any remaining user classes (new, non-conflicting ones)
-	for _, uc := range userList {
-		prefix := classPrefix(uc)
-		if _, ok := userPrefixes[prefix]; ok {
-			merged = append(merged, uc)
-			delete(userPrefixes, prefix)
+// hasChildren reports whether any child is non-nil.
+func hasChildren(children []Node) bool {
+	for _, child := range children {
+		if child != nil {
+			return true
 		}
 	}
-
-	return strings.Join(merged, " ")
-}
-
-// classPrefix extracts a "group" prefix for a class.
-// Example: "bg-red-500" → "bg-", "text-lg" → "text-", "rounded" → "rounded".
-func classPrefix(c string) string {
-	if i := strings.Index(c, "-"); i > 0 {
-		return c[:i+1] // include dash, e.g. "bg-"
-	}
-	return c
+	return false
 }
 
 // -----------------------
@@ -105,32 +65,87 @@ func classPrefix(c string) string {
 // -----------------------
 func Element(c context.Context, tag string, attrs Attrs, children ...Node) Node {
 	return func() string {
+		// Obsolete elements may be rewritten or rejected by policy
+		if out, ok := applyLegacyPolicy(c, tag, attrs, children); ok {
+			return out
+		}
+
 		// Try to get theme from context (may be nil)
 		theme, _ := ThemeFromContext(c)
+		rules := themeRulesFromContext(c)
 
-		if len(attrs) == 0 {
+		// Work on a copy so the node renders the same way every time
+		attrs := maps.Clone(attrs)
+		if attrs == nil {
 			attrs = Attrs{}
 		}
 
+		// Elements marked with NoTheme skip theming
+		if _, ok := attrs[noThemeAttr]; ok {
+			delete(attrs, noThemeAttr)
+			theme = nil
+		}
+
+		// Report the declarations AttrStyle dropped as unsafe
+		if unsafe, ok := attrs[unsafeStyleAttr]; ok {
+			delete(attrs, unsafeStyleAttr)
+			reportError(c, fmt.Errorf("wave: <%s> style: %s", tag, unsafe))
+		}
+
+		// Mark the document root with the active color scheme
+		scheme, _ := ColorSchemeFromContext(c)
+		if tag == "html" && scheme != "" {
+			if _, ok := attrs[css.ColorSchemeAttr]; !ok {
+				attrs[css.ColorSchemeAttr] = scheme
+			}
+		}
+
 		// Only apply theme styles if a theme exists
 		if theme != nil {
-			if defaultAttrs, ok := (*theme)[tag]; ok {
-				attrs = mergeAttrs(defaultAttrs, attrs) // theme first, user overrides
+			if defaultAttrs, ok := rules.attrsFor(tag, attrs, scheme); ok {
+				attrs = theme.mergeAttrs(defaultAttrs, attrs) // theme first, user overrides
 			}
 		}
 
+		// Scripts and styles carry the request's CSP nonce
+		applyNonce(c, tag, attrs)
+
+		// Replace URLs with disallowed schemes (e.g. javascript:)
+		urlPolicy(c).sanitizeURLAttrs(attrs)
+
+		// Record final classes for safelist generation
+		if col, ok := ClassCollectorFromContext(c); ok && col != nil {
+			col.Add(attrs["class"])
+		}
+
 		// Auto-generate id if missing
 		if _, ok := attrs["id"]; !ok {
-			if uid, err := uuid.NewV7(); err == nil {
-				attrs["id"] = uid.String()
+			if id, ok := generateID(c, tag); ok {
+				attrs["id"] = id
 			}
 		}
 
+		// Documents rendered under "wave dev" reload on rebuild
+		children := children
+		if endpoint, ok := LiveReloadFromContext(c); ok && tag == "body" {
+			children = append(slices.Clip(children), liveReloadScript(c, endpoint))
+		}
+
+		// Check content model while children render, if a validator is set
+		if v, ok := ValidatorFromContext(c); ok && v != nil {
+			v.enter(tag, attrs, hasChildren(children))
+			defer v.leave(tag)
+		}
+
 		// Render children
 		var expandedChildren []string
 		for _, child := range children {
-			if child != nil {
-				expandedChildren = append(expandedChildren, indentBlock(child(), 1))
+			if child == nil {
+				continue
+			}
+			// Skip children that render nothing (e.g. rejected elements)
+			if out := child(); out != "" {
+				expandedChildren = append(expandedChildren, indentBlock(out, 1))
 			}
 		}
 		childrenHTML := strings.Join(expandedChildren, "\n")
@@ -141,13 +156,18 @@ func Element(c context.Context, tag string, attrs Attrs, children ...Node) Node
 			if v == "" {
 				continue
 			}
-			attrParts = append(attrParts, fmt.Sprintf(`%s="%s"`, k, v))
+			attrParts = append(attrParts, fmt.Sprintf(`%s="%s"`, k, attrEscaper.Replace(v)))
 		}
 		sort.Strings(attrParts)
 		attrStr := strings.Join(attrParts, " ")
 
 		if childrenHTML == "" {
-			return fmt.Sprintf(`<%s %s />`, tag, attrStr)
+			// Only void elements may self-close; browsers ignore the slash
+			// on others and treat the rest of the page as their content
+			if voidElements[tag] {
+				return fmt.Sprintf(`<%s %s />`, tag, attrStr)
+			}
+			return fmt.Sprintf(`<%s %s></%s>`, tag, attrStr, tag)
 		}
 		return fmt.Sprintf(`<%s %s>
 %s
@@ -166,9 +186,6 @@ func A(c context.Context, attrs Attrs, children ...Node) Node {
 func Abbr(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "abbr", attrs, children...)
 }
-func Acronym(c context.Context, attrs Attrs, children ...Node) Node {
-	return Element(c, "acronym", attrs, children...)
-}
 func Address(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "address", attrs, children...)
 }
@@ -196,9 +213,6 @@ func Bdi(c context.Context, attrs Attrs, children ...Node) Node {
 func Bdo(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "bdo", attrs, children...)
 }
-func Big(c context.Context, attrs Attrs, children ...Node) Node {
-	return Element(c, "big", attrs, children...)
-}
 func Blockquote(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "blockquote", attrs, children...)
 }
@@ -250,9 +264,6 @@ func Dfn(c context.Context, attrs Attrs, children ...Node) Node {
 func Dialog(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "dialog", attrs, children...)
 }
-func Dir(c context.Context, attrs Attrs, children ...Node) Node {
-	return Element(c, "dir", attrs, children...)
-}
 func Div(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "div", attrs, children...)
 }
@@ -367,15 +378,6 @@ func Meter(c context.Context, attrs Attrs, children ...Node) Node {
 func Nav(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "nav", attrs, children...)
 }
-func Nobr(c context.Context, attrs Attrs, children ...Node) Node {
-	return Element(c, "nobr", attrs, children...)
-}
-func Noembed(c context.Context, attrs Attrs, children ...Node) Node {
-	return Element(c, "noembed", attrs, children...)
-}
-func Noframes(c context.Context, attrs Attrs, children ...Node) Node {
-	return Element(c, "noframes", attrs, children...)
-}
 func Noscript(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "noscript", attrs, children...)
 }
@@ -397,9 +399,6 @@ func Output(c context.Context, attrs Attrs, children ...Node) Node {
 func P(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "p", attrs, children...)
 }
-func Param(c context.Context, attrs Attrs, children ...Node) Node {
-	return Element(c, "param", attrs, children...)
-}
 func Picture(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "picture", attrs, children...)
 }
@@ -412,9 +411,6 @@ func Progress(c context.Context, attrs Attrs, children ...Node) Node {
 func Q(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "q", attrs, children...)
 }
-func Rb(c context.Context, attrs Attrs, children ...Node) Node {
-	return Element(c, "rb", attrs, children...)
-}
 func Rp(c context.Context, attrs Attrs, children ...Node) Node {
 	return Element(c, "rp", attrs, children...)
 }
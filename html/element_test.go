@@ -0,0 +1,63 @@
+package html
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/GopherGhaznix/Wave/css"
+)
+
+func TestAttributeEscaping(t *testing.T) {
+	c := WithIDGenerator(context.Background(), func(string) string { return "" })
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"plain", "hello", `title="hello"`},
+		{"quote breakout", `x" onmouseover="evil()`, `title="x&quot; onmouseover=&quot;evil()"`},
+		{"tag breakout", `"><script>x</script>`, `title="&quot;&gt;&lt;script&gt;x&lt;/script&gt;"`},
+		{"single quotes kept", `it's`, `title="it's"`},
+		{"ampersand", "a & b", `title="a &amp; b"`},
+		// Values are text, not markup: entities are escaped again
+		{"pre-escaped entity", "a &amp; b", `title="a &amp;amp; b"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Span(c, AttrTitle(tt.value))()
+			if want := "<span " + tt.want + "></span>"; got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestAttrStyleReportsUnsafe(t *testing.T) {
+	var reported []error
+	c := WithIDGenerator(context.Background(), func(string) string { return "" })
+	c = WithErrorHandler(c, func(err error) { reported = append(reported, err) })
+
+	got := Div(c, AttrStyle(css.Style{"color": "red", "width": "1px;position:fixed"}))()
+
+	if want := `<div style="color:red;"></div>`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if len(reported) != 1 || !strings.Contains(reported[0].Error(), "width") {
+		t.Errorf("reported %v, want the dropped width declaration", reported)
+	}
+}
+
+func TestStyleSheetReportsUnsafe(t *testing.T) {
+	var reported []error
+	c := WithIDGenerator(context.Background(), func(string) string { return "" })
+	c = WithErrorHandler(c, func(err error) { reported = append(reported, err) })
+
+	sheet := css.NewStylesheet(css.Rule("p", css.Prop("color", "red</style>")))
+	if got := StyleSheet(c, nil, sheet)(); strings.Contains(got, "red</style>") {
+		t.Errorf("unsafe declaration rendered: %q", got)
+	}
+	if len(reported) != 1 {
+		t.Errorf("reported %v, want the dropped declaration", reported)
+	}
+}
@@ -0,0 +1,34 @@
+package html
+
+import (
+	"context"
+	"log"
+)
+
+// unexported key type ensures uniqueness
+type errorHandlerContextKey struct{}
+
+// ErrorHandler receives errors that happen while a node renders, where
+// a Node has no way to return them, e.g. a Parallel render cut short.
+type ErrorHandler func(error)
+
+// WithErrorHandler returns a new context carrying the error handler.
+func WithErrorHandler(ctx context.Context, handle ErrorHandler) context.Context {
+	return context.WithValue(ctx, errorHandlerContextKey{}, handle)
+}
+
+// ErrorHandlerFromContext retrieves the error handler from context, if set.
+func ErrorHandlerFromContext(ctx context.Context) (ErrorHandler, bool) {
+	handle, ok := ctx.Value(errorHandlerContextKey{}).(ErrorHandler)
+	return handle, ok && handle != nil
+}
+
+// reportError passes err to the handler in context, or the standard
+// logger when none is set, so render errors are never silently lost.
+func reportError(c context.Context, err error) {
+	if handle, ok := ErrorHandlerFromContext(c); ok {
+		handle(err)
+		return
+	}
+	log.Println(err)
+}
@@ -0,0 +1,48 @@
+package html
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// unexported key type ensures uniqueness
+type idContextKey struct{}
+
+// IDGenerator returns the id for an element that has none.
+type IDGenerator func(tag string) string
+
+// WithIDGenerator returns a new context carrying the given id generator.
+func WithIDGenerator(ctx context.Context, gen IDGenerator) context.Context {
+	return context.WithValue(ctx, idContextKey{}, gen)
+}
+
+// IDGeneratorFromContext retrieves the id generator from context, if set.
+func IDGeneratorFromContext(ctx context.Context) (IDGenerator, bool) {
+	gen, ok := ctx.Value(idContextKey{}).(IDGenerator)
+	return gen, ok && gen != nil
+}
+
+// SequentialIDs numbers elements in render order ("wave-1", "wave-2", ...),
+// so rendering the same tree twice gives the same output. Use a fresh
+// generator per document.
+func SequentialIDs(prefix string) IDGenerator {
+	var n atomic.Int64
+	return func(string) string {
+		return prefix + "-" + strconv.FormatInt(n.Add(1), 10)
+	}
+}
+
+// generateID returns an id from the context generator, or a UUIDv7.
+func generateID(c context.Context, tag string) (string, bool) {
+	if gen, ok := IDGeneratorFromContext(c); ok {
+		return gen(tag), true
+	}
+	uid, err := uuid.NewV7()
+	if err != nil {
+		return "", false
+	}
+	return uid.String(), true
+}
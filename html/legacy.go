@@ -0,0 +1,157 @@
+package html
+
+import (
+	"context"
+)
+
+// unexported key type ensures uniqueness
+type legacyContextKey struct{}
+
+// LegacyMode controls how obsolete elements are rendered.
+type LegacyMode int
+
+const (
+	// LegacyAllow renders obsolete elements as-is.
+	LegacyAllow LegacyMode = iota
+	// LegacyRewrite replaces obsolete elements with their modern equivalent.
+	LegacyRewrite
+	// LegacyReject drops obsolete elements, children included.
+	LegacyReject
+)
+
+// LegacyRewriteRule describes the modern replacement for an obsolete tag.
+// An empty Tag drops the element together with its children, which suits
+// fallback-only elements like <noembed> and <noframes>.
+type LegacyRewriteRule struct {
+	Tag   string
+	Attrs Attrs
+}
+
+// LegacyPolicy decides what happens to obsolete elements at render time.
+type LegacyPolicy struct {
+	Mode LegacyMode
+
+	// Rewrites overrides DefaultLegacyRewrites per obsolete tag.
+	Rewrites map[string]LegacyRewriteRule
+
+	// Report is called for every obsolete element encountered, if set.
+	Report func(Violation)
+}
+
+// DefaultLegacyRewrites maps every obsolete element to its HTML5 replacement.
+var DefaultLegacyRewrites = map[string]LegacyRewriteRule{
+	"acronym":  {Tag: "abbr"},
+	"big":      {Tag: "span", Attrs: AttrClass("text-lg")},
+	"dir":      {Tag: "ul"},
+	"nobr":     {Tag: "span", Attrs: AttrClass("whitespace-nowrap")},
+	"noembed":  {},
+	"noframes": {},
+	"param":    {},
+	"rb":       {Tag: "span"},
+}
+
+var obsoleteElements = tagSet(
+	"acronym", "big", "dir", "nobr", "noembed", "noframes", "param", "rb",
+)
+
+// WithLegacyPolicy returns a new context carrying the given legacy policy.
+func WithLegacyPolicy(ctx context.Context, policy *LegacyPolicy) context.Context {
+	return context.WithValue(ctx, legacyContextKey{}, policy)
+}
+
+// LegacyPolicyFromContext retrieves the legacy policy from context, if set.
+func LegacyPolicyFromContext(ctx context.Context) (*LegacyPolicy, bool) {
+	p, ok := ctx.Value(legacyContextKey{}).(*LegacyPolicy)
+	return p, ok
+}
+
+// rule returns the rewrite for tag, preferring the policy's own table.
+func (p *LegacyPolicy) rule(tag string) LegacyRewriteRule {
+	if r, ok := p.Rewrites[tag]; ok {
+		return r
+	}
+	return DefaultLegacyRewrites[tag]
+}
+
+// applyLegacyPolicy renders an obsolete element according to the policy
+// in context. It reports false when the element should render normally.
+func applyLegacyPolicy(c context.Context, tag string, attrs Attrs, children []Node) (string, bool) {
+	policy, _ := LegacyPolicyFromContext(c)
+	if policy == nil || policy.Mode == LegacyAllow || !obsoleteElements[tag] {
+		return "", false
+	}
+
+	if policy.Report != nil {
+		policy.Report(Violation{
+			Kind:    ViolationObsolete,
+			Tag:     tag,
+			Message: "element is obsolete in HTML5",
+		})
+	}
+
+	rule := policy.rule(tag)
+	if policy.Mode == LegacyReject || rule.Tag == "" {
+		return "", true
+	}
+	return Element(c, rule.Tag, mergeAttrs(rule.Attrs, attrs), children...)(), true
+}
+
+// -----------------------
+// Obsolete Elements Wrappers
+// -----------------------
+
+// Acronym renders the obsolete <acronym> element.
+//
+// Deprecated: <acronym> is obsolete in HTML5; use Abbr.
+func Acronym(c context.Context, attrs Attrs, children ...Node) Node {
+	return Element(c, "acronym", attrs, children...)
+}
+
+// Big renders the obsolete <big> element.
+//
+// Deprecated: <big> is obsolete in HTML5; use Span with a font-size class or CSS.
+func Big(c context.Context, attrs Attrs, children ...Node) Node {
+	return Element(c, "big", attrs, children...)
+}
+
+// Dir renders the obsolete <dir> element.
+//
+// Deprecated: <dir> is obsolete in HTML5; use Ul.
+func Dir(c context.Context, attrs Attrs, children ...Node) Node {
+	return Element(c, "dir", attrs, children...)
+}
+
+// Nobr renders the obsolete <nobr> element.
+//
+// Deprecated: <nobr> is obsolete in HTML5; use Span with "white-space: nowrap".
+func Nobr(c context.Context, attrs Attrs, children ...Node) Node {
+	return Element(c, "nobr", attrs, children...)
+}
+
+// Noembed renders the obsolete <noembed> element.
+//
+// Deprecated: <noembed> is obsolete in HTML5; put fallback content inside Object.
+func Noembed(c context.Context, attrs Attrs, children ...Node) Node {
+	return Element(c, "noembed", attrs, children...)
+}
+
+// Noframes renders the obsolete <noframes> element.
+//
+// Deprecated: <noframes> is obsolete in HTML5; frames are no longer supported.
+func Noframes(c context.Context, attrs Attrs, children ...Node) Node {
+	return Element(c, "noframes", attrs, children...)
+}
+
+// Param renders the obsolete <param> element.
+//
+// Deprecated: <param> is obsolete in HTML5; pass parameters through Object's data URL.
+func Param(c context.Context, attrs Attrs, children ...Node) Node {
+	return Element(c, "param", attrs, children...)
+}
+
+// Rb renders the obsolete <rb> element.
+//
+// Deprecated: <rb> is obsolete in HTML5; place base text directly inside Ruby.
+func Rb(c context.Context, attrs Attrs, children ...Node) Node {
+	return Element(c, "rb", attrs, children...)
+}
@@ -0,0 +1,48 @@
+package html
+
+import (
+	"context"
+	"os"
+	"strconv"
+)
+
+// unexported key type ensures uniqueness
+type liveReloadContextKey struct{}
+
+// LiveReloadEnv is set by "wave dev" to the live-reload endpoint of the
+// development server. It is never set in production.
+const LiveReloadEnv = "WAVE_LIVE_RELOAD"
+
+// WithLiveReload returns a new context that makes every <body> rendered
+// with it include a script reloading the page when the server sends a
+// "reload" Server-Sent Event on endpoint.
+func WithLiveReload(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, liveReloadContextKey{}, endpoint)
+}
+
+// LiveReloadFromContext retrieves the live-reload endpoint from context,
+// if set.
+func LiveReloadFromContext(ctx context.Context) (string, bool) {
+	endpoint, ok := ctx.Value(liveReloadContextKey{}).(string)
+	return endpoint, ok && endpoint != ""
+}
+
+// DevContext enables live reload when running under "wave dev", and
+// returns ctx unchanged otherwise, so it is safe to leave in production.
+//
+// Example:
+//
+//	c := html.DevContext(html.WithTheme(context.Background(), theme))
+func DevContext(ctx context.Context) context.Context {
+	if endpoint := os.Getenv(LiveReloadEnv); endpoint != "" {
+		return WithLiveReload(ctx, endpoint)
+	}
+	return ctx
+}
+
+// liveReloadScript listens for reload events from the dev server.
+func liveReloadScript(c context.Context, endpoint string) Node {
+	return Script(c, nil, Text(
+		`new EventSource(`+strconv.Quote(endpoint)+`).addEventListener("reload", () => location.reload());`,
+	))
+}
@@ -0,0 +1,210 @@
+package html
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// unexported key type ensures uniqueness
+type parallelContextKey struct{}
+
+// DefaultParallelLimit bounds the goroutines of a Parallel render when
+// the context sets no limit.
+const DefaultParallelLimit = 8
+
+// WithParallelLimit returns a new context limiting how many children of
+// a Parallel node render at once.
+func WithParallelLimit(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, parallelContextKey{}, n)
+}
+
+// ParallelLimitFromContext retrieves the parallel limit from context, or
+// DefaultParallelLimit when none is set.
+func ParallelLimitFromContext(ctx context.Context) int {
+	if n, ok := ctx.Value(parallelContextKey{}).(int); ok && n > 0 {
+		return n
+	}
+	return DefaultParallelLimit
+}
+
+// ParallelIncomplete is rendered after the output of a Parallel node
+// whose context was done before every child rendered.
+const ParallelIncomplete = "<!-- wave: parallel render incomplete -->"
+
+// Parallel builds and renders children concurrently and outputs them in
+// order, as if they were siblings. Children not started when c is done
+// are skipped: the output then ends with ParallelIncomplete and the
+// cause is reported to the ErrorHandler in context. Use ParallelLoad to
+// get the error back instead.
+//
+// Each child is built with a context derived from c, so canceling c
+// stops it, and with its own id prefix drawn from the IDGenerator in
+// context before fanning out, so SequentialIDs come out the same on
+// every render. With a Validator in context, children render one at a
+// time, as validation tracks the element being rendered.
+//
+// Example:
+//
+//	html.Main(c, nil,
+//		html.Parallel(c, OrdersWidget, RevenueWidget, VisitorsWidget),
+//	)
+func Parallel(c context.Context, children ...func(context.Context) Node) Node {
+	return func() string {
+		ids := childIDs(c, len(children))
+		renders := make([]func(context.Context) (string, error), len(children))
+		for i, child := range children {
+			// Built with c rather than the render context, which is
+			// canceled on return while Suspense loads may still run
+			renders[i] = func(context.Context) (string, error) {
+				if child == nil {
+					return "", nil
+				}
+				if node := child(withIDs(c, ids[i])); node != nil {
+					return node(), nil
+				}
+				return "", nil
+			}
+		}
+		out, err := renderParallel(c, renders)
+		if err != nil {
+			reportError(c, fmt.Errorf("wave: parallel: %w", err))
+			out = append(out, ParallelIncomplete)
+		}
+		return joinRendered(out)
+	}
+}
+
+// ParallelLoad runs the loads concurrently, rendering each resulting node
+// on its goroutine, and returns their output in order. The first error
+// cancels the context of the loads still running and is returned. Like
+// Parallel, every load gets its own id prefix.
+//
+// The context passed to the loads is canceled once ParallelLoad returns,
+// so nodes suspending on it (see Suspense) belong in Parallel instead.
+//
+// Example:
+//
+//	widgets, err := html.ParallelLoad(c, loadOrders, loadRevenue, loadVisitors)
+//	if err != nil {
+//		http.Error(w, err.Error(), http.StatusBadGateway)
+//		return
+//	}
+func ParallelLoad(c context.Context, loads ...func(context.Context) (Node, error)) (Node, error) {
+	ids := childIDs(c, len(loads))
+	renders := make([]func(context.Context) (string, error), len(loads))
+	for i, load := range loads {
+		renders[i] = func(ctx context.Context) (string, error) {
+			node, err := load(withIDs(ctx, ids[i]))
+			if err != nil || node == nil {
+				return "", err
+			}
+			return node(), nil
+		}
+	}
+	out, err := renderParallel(c, renders)
+	if err != nil {
+		return nil, err
+	}
+	return Text(joinRendered(out)), nil
+}
+
+// childIDs draws an id prefix per child from the generator in context,
+// in order, so ids do not depend on which child renders first. Without
+// a generator, ids are UUIDs and every entry is nil.
+func childIDs(c context.Context, n int) []IDGenerator {
+	ids := make([]IDGenerator, n)
+	if gen, ok := IDGeneratorFromContext(c); ok {
+		for i := range ids {
+			ids[i] = SequentialIDs(gen("parallel"))
+		}
+	}
+	return ids
+}
+
+// withIDs returns ctx with gen as its id generator, if not nil.
+func withIDs(ctx context.Context, gen IDGenerator) context.Context {
+	if gen == nil {
+		return ctx
+	}
+	return WithIDGenerator(ctx, gen)
+}
+
+// renderParallel runs renders on at most ParallelLimitFromContext(c)
+// goroutines. It stops starting renders after the first error or when c
+// is done, returning the cause unless every render completed, and
+// re-panics in the caller if a render panics.
+func renderParallel(c context.Context, renders []func(context.Context) (string, error)) ([]string, error) {
+	limit := ParallelLimitFromContext(c)
+	if v, ok := ValidatorFromContext(c); ok && v != nil {
+		limit = 1
+	}
+
+	ctx, cancel := context.WithCancelCause(c)
+	defer cancel(nil)
+
+	var (
+		out      = make([]string, len(renders))
+		rendered = make([]bool, len(renders))
+		sem      = make(chan struct{}, limit)
+		wg       sync.WaitGroup
+		panicked any
+		once     sync.Once
+	)
+
+start:
+	for i, render := range renders {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break start
+		}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer func() {
+				if p := recover(); p != nil {
+					once.Do(func() { panicked = p })
+					cancel(fmt.Errorf("wave: parallel render panicked: %v", p))
+				}
+			}()
+
+			s, err := render(ctx)
+			if err != nil {
+				cancel(err)
+				return
+			}
+			out[i] = s
+			rendered[i] = true
+		}()
+	}
+	wg.Wait()
+
+	if panicked != nil {
+		panic(panicked)
+	}
+	if slices.Contains(rendered, false) {
+		return out, context.Cause(ctx)
+	}
+	return out, nil
+}
+
+// joinRendered joins sibling output, skipping children that rendered
+// nothing, like Element does.
+func joinRendered(out []string) string {
+	parts := make([]string, 0, len(out))
+	for _, s := range out {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,145 @@
+package html
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelOrder(t *testing.T) {
+	c := WithIDGenerator(context.Background(), SequentialIDs("wave"))
+
+	var children []func(context.Context) Node
+	var want []string
+	for i := range 20 {
+		children = append(children, func(c context.Context) Node {
+			// Later children finish first
+			time.Sleep(time.Duration(20-i) * time.Millisecond)
+			return Text(strconv.Itoa(i))
+		})
+		want = append(want, strconv.Itoa(i))
+	}
+	children = append(children, nil, func(context.Context) Node { return nil })
+
+	if got := Parallel(c, children...)(); got != strings.Join(want, "\n") {
+		t.Errorf("Parallel() = %q, want %q", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestParallelIDs(t *testing.T) {
+	render := func() string {
+		c := WithIDGenerator(context.Background(), SequentialIDs("wave"))
+		var children []func(context.Context) Node
+		for i := range 4 {
+			children = append(children, func(c context.Context) Node {
+				time.Sleep(time.Duration(i%2) * time.Millisecond)
+				return Span(c, nil, Span(c, nil))
+			})
+		}
+		return Div(c, nil, Parallel(c, children...))()
+	}
+
+	first := render()
+	for _, id := range []string{`"wave-1"`, `"wave-2-1"`, `"wave-2-2"`, `"wave-5-2"`} {
+		if !strings.Contains(first, id) {
+			t.Errorf("output lacks id %s:\n%s", id, first)
+		}
+	}
+	for range 10 {
+		if got := render(); got != first {
+			t.Fatalf("ids changed between renders:\n%s\nthen\n%s", first, got)
+		}
+	}
+}
+
+func TestParallelLimit(t *testing.T) {
+	const limit = 3
+	c := WithParallelLimit(context.Background(), limit)
+
+	var running, peak atomic.Int32
+	var children []func(context.Context) Node
+	for range 12 {
+		children = append(children, func(context.Context) Node {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return Text("x")
+		})
+	}
+	Parallel(c, children...)()
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("peak concurrency = %d, want at most %d", got, limit)
+	}
+}
+
+func TestParallelCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	var reported []error
+	c := WithErrorHandler(WithParallelLimit(ctx, 1), func(err error) {
+		mu.Lock()
+		reported = append(reported, err)
+		mu.Unlock()
+	})
+
+	var started atomic.Int32
+	children := []func(context.Context) Node{
+		func(c context.Context) Node {
+			started.Add(1)
+			cancel()
+			<-c.Done() // children see the cancellation
+			return Text("first")
+		},
+		func(context.Context) Node {
+			started.Add(1)
+			return Text("second")
+		},
+	}
+	got := Parallel(c, children...)()
+
+	if want := "first\n" + ParallelIncomplete; got != want {
+		t.Errorf("Parallel() = %q, want %q", got, want)
+	}
+	if n := started.Load(); n != 1 {
+		t.Errorf("%d children started after cancel, want 1", n)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], context.Canceled) {
+		t.Errorf("reported %v, want one context.Canceled", reported)
+	}
+}
+
+func TestParallelLoadError(t *testing.T) {
+	errLoad := errors.New("load failed")
+	c := WithParallelLimit(context.Background(), 2)
+
+	var canceled atomic.Bool
+	_, err := ParallelLoad(c,
+		func(context.Context) (Node, error) { return nil, errLoad },
+		func(c context.Context) (Node, error) {
+			select {
+			case <-c.Done():
+				canceled.Store(true)
+			case <-time.After(time.Second):
+			}
+			return Text("slow"), nil
+		},
+	)
+	if !errors.Is(err, errLoad) {
+		t.Errorf("ParallelLoad() error = %v, want %v", err, errLoad)
+	}
+	if !canceled.Load() {
+		t.Error("running load was not canceled by the first error")
+	}
+}
@@ -0,0 +1,343 @@
+package html
+
+import (
+	"context"
+	stdhtml "html"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SanitizePolicy lists the elements and attributes allowed to survive
+// sanitization. Elements not listed are unwrapped (their text is kept);
+// attributes not listed are dropped. URL attributes are additionally
+// checked against the URLPolicy in context when the nodes render.
+type SanitizePolicy struct {
+	// Elements maps an allowed tag to its allowed attributes.
+	Elements map[string][]string
+
+	// SetAttrs are set on every kept element of a tag, overriding input,
+	// e.g. rel="nofollow" on links.
+	SetAttrs map[string]Attrs
+}
+
+// BasicFormattingPolicy keeps paragraphs, lists, quotes, code and inline
+// text formatting.
+var BasicFormattingPolicy = &SanitizePolicy{
+	Elements: map[string][]string{
+		"p": nil, "br": nil, "hr": nil, "span": nil,
+		"b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil,
+		"del": nil, "ins": nil, "mark": nil, "small": nil, "sub": nil, "sup": nil,
+		"code": nil, "pre": nil, "kbd": nil, "samp": nil,
+		"ul": nil, "ol": {"start", "reversed"}, "li": {"value"},
+		"blockquote": {"cite"}, "q": {"cite"}, "abbr": {"title"},
+	},
+}
+
+// LinksPolicy keeps links, marked as user-generated and nofollow.
+var LinksPolicy = &SanitizePolicy{
+	Elements: map[string][]string{
+		"a": {"href", "title", "hreflang"},
+	},
+	SetAttrs: map[string]Attrs{
+		"a": {"rel": "nofollow ugc noopener noreferrer"},
+	},
+}
+
+// ImagesPolicy keeps images and figures, loaded lazily.
+var ImagesPolicy = &SanitizePolicy{
+	Elements: map[string][]string{
+		"img":        {"src", "srcset", "alt", "title", "width", "height"},
+		"figure":     nil,
+		"figcaption": nil,
+	},
+	SetAttrs: map[string]Attrs{
+		"img": {"loading": "lazy"},
+	},
+}
+
+// CombinePolicies returns a policy allowing everything any of the given
+// policies allow.
+func CombinePolicies(policies ...*SanitizePolicy) *SanitizePolicy {
+	combined := &SanitizePolicy{
+		Elements: map[string][]string{},
+		SetAttrs: map[string]Attrs{},
+	}
+	for _, p := range policies {
+		if p == nil {
+			continue
+		}
+		for tag, attrs := range p.Elements {
+			combined.Elements[tag] = append(combined.Elements[tag], attrs...)
+		}
+		for tag, attrs := range p.SetAttrs {
+			combined.SetAttrs[tag] = mergeAttrs(combined.SetAttrs[tag], attrs)
+		}
+	}
+	return combined
+}
+
+// Sanitize parses untrusted HTML and returns it as Wave nodes, keeping
+// only what the policies allow. Text is escaped, and comments as well as
+// the content of <script>, <style> and similar elements are removed.
+// With no policies, all markup is stripped and only text remains.
+//
+// Example:
+//
+//	html.Div(c, nil, html.Sanitize(c, bio, html.BasicFormattingPolicy, html.LinksPolicy))
+func Sanitize(c context.Context, input string, policies ...*SanitizePolicy) Node {
+	policy := CombinePolicies(policies...)
+	root := parseFragment(input)
+	nodes := policy.nodes(c, root.children)
+	return func() string {
+		var parts []string
+		for _, n := range nodes {
+			if out := n(); out != "" {
+				parts = append(parts, out)
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+}
+
+// nodes converts parsed elements into Wave nodes, unwrapping elements the
+// policy does not allow.
+func (p *SanitizePolicy) nodes(c context.Context, parsed []*fragmentNode) []Node {
+	var nodes []Node
+	for _, n := range parsed {
+		if n.tag == "" {
+			if text := strings.TrimSpace(n.text); text != "" {
+				nodes = append(nodes, Text(stdhtml.EscapeString(text)))
+			}
+			continue
+		}
+
+		allowed, ok := p.Elements[n.tag]
+		children := p.nodes(c, n.children)
+		if !ok {
+			nodes = append(nodes, children...)
+			continue
+		}
+
+		attrs := Attrs{}
+		for name, value := range n.attrs {
+			if slices.Contains(allowed, name) {
+				attrs[name] = value
+			}
+		}
+		maps.Copy(attrs, p.SetAttrs[n.tag])
+		nodes = append(nodes, Element(c, n.tag, attrs, children...))
+	}
+	return nodes
+}
+
+// -----------------------
+// Fragment Parser
+// -----------------------
+
+// fragmentNode is an element, or a text node when tag is empty.
+type fragmentNode struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*fragmentNode
+}
+
+// rawTextElements have content that is not markup; it is dropped whole.
+var rawTextElements = tagSet(
+	"script", "style", "textarea", "title", "xmp", "iframe",
+	"noembed", "noframes", "noscript", "plaintext", "template",
+)
+
+// closesParagraph lists the elements whose start tag closes an open <p>.
+var closesParagraph = tagSet(
+	"address", "article", "aside", "blockquote", "details", "div", "dl",
+	"fieldset", "figcaption", "figure", "footer", "form", "h1", "h2", "h3",
+	"h4", "h5", "h6", "header", "hr", "main", "nav", "ol", "p", "pre",
+	"section", "table", "ul",
+)
+
+// impliedEnd reports whether opening tag next implicitly closes open,
+// as in "<li>one<li>two" or "<p>one<p>two".
+func impliedEnd(open, next string) bool {
+	switch open {
+	case "p":
+		return closesParagraph[next]
+	case "li":
+		return next == "li"
+	case "dt", "dd":
+		return next == "dt" || next == "dd"
+	case "option":
+		return next == "option"
+	}
+	return false
+}
+
+// parseFragment builds a tree from an HTML fragment. It is deliberately
+// forgiving: stray end tags are ignored, unclosed elements are closed at
+// the end, and a '<' that does not start a tag is treated as text.
+func parseFragment(input string) *fragmentNode {
+	root := &fragmentNode{}
+	stack := []*fragmentNode{root}
+	current := func() *fragmentNode { return stack[len(stack)-1] }
+
+	addText := func(s string) {
+		if s == "" {
+			return
+		}
+		cur := current()
+		if n := len(cur.children); n > 0 && cur.children[n-1].tag == "" {
+			cur.children[n-1].text += stdhtml.UnescapeString(s)
+			return
+		}
+		cur.children = append(cur.children, &fragmentNode{text: stdhtml.UnescapeString(s)})
+	}
+
+	for len(input) > 0 {
+		i := strings.IndexByte(input, '<')
+		if i < 0 {
+			addText(input)
+			break
+		}
+		addText(input[:i])
+		input = input[i:]
+
+		switch {
+		case strings.HasPrefix(input, "<!--"):
+			input = skipPast(input[4:], "-->")
+
+		case strings.HasPrefix(input, "<!"), strings.HasPrefix(input, "<?"):
+			input = skipPast(input[2:], ">")
+
+		case strings.HasPrefix(input, "</"):
+			name, rest := readTagName(input[2:])
+			if name == "" {
+				addText("</")
+				input = input[2:]
+				continue
+			}
+			input = skipPast(rest, ">")
+			for j := len(stack) - 1; j > 0; j-- {
+				if stack[j].tag == name {
+					stack = stack[:j]
+					break
+				}
+			}
+
+		default:
+			name, rest := readTagName(input[1:])
+			if name == "" {
+				addText("<")
+				input = input[1:]
+				continue
+			}
+			attrs, rest, selfClosing := readAttrs(rest)
+			input = rest
+
+			if rawTextElements[name] {
+				input = skipRawText(input, name)
+				continue
+			}
+			if len(stack) > 1 && impliedEnd(current().tag, name) {
+				stack = stack[:len(stack)-1]
+			}
+			n := &fragmentNode{tag: name, attrs: attrs}
+			current().children = append(current().children, n)
+			if !voidElements[name] && !selfClosing {
+				stack = append(stack, n)
+			}
+		}
+	}
+	return root
+}
+
+// readTagName reads a tag name starting with a letter, lowercased.
+func readTagName(s string) (string, string) {
+	if s == "" || !isASCIILetter(s[0]) {
+		return "", s
+	}
+	end := strings.IndexAny(s, " \t\n\r\f/>")
+	if end < 0 {
+		end = len(s)
+	}
+	return strings.ToLower(s[:end]), s[end:]
+}
+
+// readAttrs reads attributes up to and including the closing '>'.
+func readAttrs(s string) (map[string]string, string, bool) {
+	attrs := map[string]string{}
+	selfClosing := false
+	for {
+		s = strings.TrimLeft(s, " \t\n\r\f")
+		if s == "" {
+			return attrs, s, selfClosing
+		}
+		switch s[0] {
+		case '>':
+			return attrs, s[1:], selfClosing
+		case '/':
+			selfClosing = true
+			s = s[1:]
+			continue
+		}
+		selfClosing = false
+
+		end := strings.IndexAny(s, " \t\n\r\f/>=")
+		if end < 0 {
+			end = len(s)
+		}
+		if end == 0 { // stray '='
+			s = s[1:]
+			continue
+		}
+		name := strings.ToLower(s[:end])
+		s = strings.TrimLeft(s[end:], " \t\n\r\f")
+
+		value := name // boolean attribute
+		if strings.HasPrefix(s, "=") {
+			s = strings.TrimLeft(s[1:], " \t\n\r\f")
+			value, s = readAttrValue(s)
+		}
+		if _, seen := attrs[name]; !seen {
+			attrs[name] = value
+		}
+	}
+}
+
+// readAttrValue reads a quoted or unquoted attribute value.
+func readAttrValue(s string) (string, string) {
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		end := strings.IndexByte(s[1:], s[0])
+		if end < 0 {
+			return stdhtml.UnescapeString(s[1:]), ""
+		}
+		return stdhtml.UnescapeString(s[1 : end+1]), s[end+2:]
+	}
+	end := strings.IndexAny(s, " \t\n\r\f>")
+	if end < 0 {
+		end = len(s)
+	}
+	return stdhtml.UnescapeString(s[:end]), s[end:]
+}
+
+// skipRawText skips past the end tag of a raw text element.
+func skipRawText(s, name string) string {
+	lower := strings.ToLower(s)
+	i := strings.Index(lower, "</"+name)
+	if i < 0 {
+		return ""
+	}
+	return skipPast(s[i:], ">")
+}
+
+// skipPast returns what follows the first occurrence of sep, or "".
+func skipPast(s, sep string) string {
+	if _, after, ok := strings.Cut(s, sep); ok {
+		return after
+	}
+	return ""
+}
+
+func isASCIILetter(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
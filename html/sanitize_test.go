@@ -0,0 +1,173 @@
+package html
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// dumpFragment renders a parsed tree compactly: tag[attr=value](children).
+func dumpFragment(nodes []*fragmentNode) string {
+	var parts []string
+	for _, n := range nodes {
+		if n.tag == "" {
+			parts = append(parts, fmt.Sprintf("%q", n.text))
+			continue
+		}
+		s := n.tag
+		if len(n.attrs) > 0 {
+			var attrs []string
+			for k, v := range n.attrs {
+				attrs = append(attrs, k+"="+v)
+			}
+			sort.Strings(attrs)
+			s += "[" + strings.Join(attrs, " ") + "]"
+		}
+		parts = append(parts, s+"("+dumpFragment(n.children)+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestParseFragment(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"text", "hello", `"hello"`},
+		{"nested", "<b>x<i>y</i></b>", `b("x" i("y"))`},
+		{"empty element", "<b></b>", `b()`},
+		{"unclosed", "<b>x<i>y", `b("x" i("y"))`},
+		{"stray end tag", "</i>x</b>", `"x"`},
+		{"mismatched end tag", "<b><i>x</b>y", `b(i("x")) "y"`},
+		{"void", "a<br>b", `"a" br() "b"`},
+		{"self-closing", "<span/>x", `span() "x"`},
+		{"implied li end", "<ul><li>1<li>2</ul>", `ul(li("1") li("2"))`},
+		{"implied p end", "<p>a<p>b<div>c</div>", `p("a") p("b") div("c")`},
+		{"uppercase", "<B CLASS=x>y</B>", `b[class=x]("y")`},
+		{"attribute forms", `<a href="x" title='y' data-z=1 hidden>t</a>`, `a[data-z=1 hidden=hidden href=x title=y]("t")`},
+		{"duplicate attribute", `<a href="first" href="second">`, `a[href=first]()`},
+		{"entities", "<a href=\"&#106;avascript:x\">&lt;b&gt; &amp;</a>", `a[href=javascript:x]("<b> &")`},
+		{"script dropped", "<script>alert('<b>')</script>ok", `"ok"`},
+		{"style dropped", "<style>p{color:red}</style>ok", `"ok"`},
+		{"unterminated raw text", "<script>alert(1)", ``},
+		{"raw text case", "<SCRIPT>x</ScRiPt>ok", `"ok"`},
+		{"comment dropped", "a<!-- <b>x</b> -->b", `"ab"`},
+		{"unterminated comment", "a<!-- b", `"a"`},
+		{"doctype dropped", "<!DOCTYPE html>x", `"x"`},
+		{"lone less-than", "1 < 2 <3", `"1 < 2 <3"`},
+		{"lone end marker", "a </ b", `"a </ b"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dumpFragment(parseFragment(tt.in).children); got != tt.want {
+				t.Errorf("parseFragment(%q)\n got %s\nwant %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	// Suppress auto ids so outputs are stable
+	c := WithIDGenerator(context.Background(), func(string) string { return "" })
+
+	all := []*SanitizePolicy{BasicFormattingPolicy, LinksPolicy, ImagesPolicy}
+	tests := []struct {
+		name     string
+		in       string
+		policies []*SanitizePolicy
+		want     string
+	}{
+		{
+			"text only without policies",
+			"<b>bold</b> <script>x</script>&lt;i&gt;",
+			nil,
+			"bold\n&lt;i&gt;",
+		},
+		{
+			"empty element is closed",
+			"<b></b>",
+			all,
+			"<b ></b>",
+		},
+		{
+			"empty link is closed",
+			`<a href="https://evil.example"></a>`,
+			all,
+			`<a href="https://evil.example" rel="nofollow ugc noopener noreferrer"></a>`,
+		},
+		{
+			"unclosed tags are closed",
+			"<b>x<i>y",
+			all,
+			"<b >\n  x\n  <i >\n    y\n  </i>\n</b>",
+		},
+		{
+			"disallowed attributes dropped",
+			`<p class="x" onclick="evil()" style="color:red">hi</p>`,
+			all,
+			"<p >\n  hi\n</p>",
+		},
+		{
+			"disallowed element unwrapped",
+			"<div><b>x</b></div>",
+			all,
+			"<b >\n  x\n</b>",
+		},
+		{
+			"links need the links policy",
+			`<a href="https://ok.example">x</a>`,
+			[]*SanitizePolicy{BasicFormattingPolicy},
+			"x",
+		},
+		{
+			"javascript url",
+			`<a href="javascript:alert(1)">x</a>`,
+			all,
+			`<a href="about:invalid#wave-unsafe-url" rel="nofollow ugc noopener noreferrer">` + "\n  x\n</a>",
+		},
+		{
+			"entity-encoded javascript url",
+			`<a href="&#106;avascript&colon;alert(1)">x</a>`,
+			all,
+			`<a href="about:invalid#wave-unsafe-url" rel="nofollow ugc noopener noreferrer">` + "\n  x\n</a>",
+		},
+		{
+			"entity-encoded tab in scheme",
+			`<a href="java&#x09;script:alert(1)">x</a>`,
+			all,
+			`<a href="about:invalid#wave-unsafe-url" rel="nofollow ugc noopener noreferrer">` + "\n  x\n</a>",
+		},
+		{
+			"image with event handler",
+			`<img src="/cat.png" alt="a &quot;cat&quot;" onerror="evil()">`,
+			all,
+			`<img alt="a &quot;cat&quot;" loading="lazy" src="/cat.png" />`,
+		},
+		{
+			"data url image",
+			`<img src="data:image/svg+xml,<svg onload=evil()>">`,
+			all,
+			`<img loading="lazy" src="about:invalid#wave-unsafe-url" />`,
+		},
+		{
+			"raw text content dropped",
+			"<textarea><b>x</b></textarea><iframe src=x></iframe>y",
+			all,
+			"y",
+		},
+		{
+			"attribute breakout escaped",
+			`<abbr title='"><script>x</script>'>t</abbr>`,
+			all,
+			`<abbr title="&quot;&gt;&lt;script&gt;x&lt;/script&gt;">` + "\n  t\n</abbr>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(c, tt.in, tt.policies...)(); got != tt.want {
+				t.Errorf("Sanitize(%q)\n got %q\nwant %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
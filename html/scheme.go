@@ -0,0 +1,123 @@
+package html
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// unexported key type ensures uniqueness
+type colorSchemeContextKey struct{}
+
+// SchemeStrategy controls how a theme's color schemes are rendered.
+type SchemeStrategy int
+
+const (
+	// SchemeInherit, the zero value, keeps the strategy of the themes
+	// merged before (see MergeThemes), and renders like SchemeVariant
+	// when none set one.
+	SchemeInherit SchemeStrategy = iota
+
+	// SchemeVariant renders every scheme at once as Tailwind variant
+	// classes: a "dark" scheme class "bg-gray-900" becomes
+	// "dark:bg-gray-900". Tailwind then switches on prefers-color-scheme,
+	// or on the data-color-scheme attribute set on Html when its dark
+	// variant is configured with a selector. Only classes are rendered.
+	SchemeVariant
+
+	// SchemeServer applies only the scheme selected in the context with
+	// WithColorScheme, with all of its attributes, as if they were part
+	// of the element defaults. Use it when the server knows the user's
+	// choice, e.g. from a cookie.
+	SchemeServer
+)
+
+// WithScheme returns a new Theme based on t with the named color
+// scheme added. If the scheme already exists, elementStyle is merged
+// on top of it.
+//
+// Example:
+//
+//	NewDefaultTheme().WithScheme("dark", map[string]Attrs{
+//	  "body": AttrClass("bg-gray-900 text-gray-100"),
+//	  "p":    AttrClass("text-gray-300"),
+//	})
+func (t *Theme) WithScheme(name string, elementStyle map[string]Attrs) *Theme {
+	return MergeThemes(t, &Theme{
+		Schemes: map[string]map[string]Attrs{name: elementStyle},
+	})
+}
+
+// WithSchemeStrategy returns a new Theme based on t rendering its
+// schemes with the given strategy.
+func (t *Theme) WithSchemeStrategy(strategy SchemeStrategy) *Theme {
+	merged := MergeThemes(t)
+	merged.SchemeStrategy = strategy
+	return merged
+}
+
+// SchemeNames returns the theme's color scheme names, sorted.
+func (t *Theme) SchemeNames() []string {
+	names := make([]string, 0, len(t.Schemes))
+	for name := range t.Schemes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// WithColorScheme returns a new context selecting the active color
+// scheme. Html elements rendered with it carry a data-color-scheme
+// attribute, and themes using SchemeServer apply that scheme.
+func WithColorScheme(ctx context.Context, scheme string) context.Context {
+	return context.WithValue(ctx, colorSchemeContextKey{}, scheme)
+}
+
+// ColorSchemeFromContext retrieves the active color scheme, if set.
+func ColorSchemeFromContext(ctx context.Context) (string, bool) {
+	s, ok := ctx.Value(colorSchemeContextKey{}).(string)
+	return s, ok
+}
+
+// applySchemes layers the theme's color scheme rules on top of themed.
+func (r *themeRules) applySchemes(themed Attrs, ok bool, tag string, attrs Attrs, active string) (Attrs, bool) {
+	if r.theme.SchemeStrategy == SchemeServer {
+		if active == "" {
+			return themed, ok
+		}
+		return r.applyRules(themed, ok, r.schemes[active], tag, attrs)
+	}
+
+	for _, name := range r.schemeNames {
+		for _, rule := range r.schemes[name].matching(tag, attrs) {
+			if class := prefixClasses(name, rule["class"]); class != "" {
+				themed, ok = r.theme.mergeAttrs(themed, AttrClass(class)), true
+			}
+		}
+	}
+	return themed, ok
+}
+
+// prefixClasses adds a Tailwind variant to every class in list.
+func prefixClasses(variant, list string) string {
+	classes := strings.Fields(list)
+	for i, c := range classes {
+		classes[i] = variant + ":" + c
+	}
+	return strings.Join(classes, " ")
+}
+
+// ColorSchemeMeta renders <meta name="color-scheme"> listing "light",
+// and "dark" when the theme in context has a dark scheme, so browsers
+// style form controls and scrollbars to match. Custom schemes are left
+// out: the browser only knows light and dark.
+func ColorSchemeMeta(c context.Context) Node {
+	schemes := []string{"light"}
+	if theme, _ := ThemeFromContext(c); theme != nil && slices.Contains(theme.SchemeNames(), "dark") {
+		schemes = append(schemes, "dark")
+	}
+	return Meta(c, Attributes(
+		AttrMetaName("color-scheme"),
+		Attrs{"content": strings.Join(schemes, " ")},
+	))
+}
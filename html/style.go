@@ -0,0 +1,20 @@
+package html
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GopherGhaznix/Wave/css"
+)
+
+// StyleSheet renders a css.Stylesheet inside a <style> element.
+// Unsafe declarations, selectors and at-rules are dropped and reported
+// to the ErrorHandler in context.
+func StyleSheet(c context.Context, attrs Attrs, sheet *css.Stylesheet) Node {
+	return func() string {
+		if err := sheet.Check(); err != nil {
+			reportError(c, fmt.Errorf("wave: <style>: %w", err))
+		}
+		return Style(c, attrs, Text(sheet.String()))()
+	}
+}
@@ -0,0 +1,231 @@
+package html
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// unexported key type ensures uniqueness
+type streamContextKey struct{}
+
+// Stream writes a document in chunks: first the shell with placeholders
+// for every Suspense, then each suspended section as soon as it resolves,
+// in whatever order they finish, with a small script that swaps it into
+// place. Sections are written after the shell, which browsers append to
+// the body, so the script and template never show up in the page.
+//
+// Use one Stream per response, and make sure Render runs once a
+// Suspense has rendered with it: loads wait for it until c is done.
+type Stream struct {
+	w     io.Writer
+	flush func()
+
+	mu        sync.Mutex
+	count     int
+	pending   int
+	swapReady bool
+	results   chan suspenseResult
+}
+
+// suspenseResult is a loaded section waiting to be streamed.
+type suspenseResult struct {
+	id        string
+	node      Node
+	err       error
+	validator *Validator // the load's own fork, if validating
+	ancestors []string   // elements around the placeholder, for validation
+}
+
+// NewStream returns a Stream writing to w, flushing after every chunk
+// when w is an http.ResponseWriter.
+func NewStream(w io.Writer) *Stream {
+	s := &Stream{w: w, flush: func() {}, results: make(chan suspenseResult)}
+	if f, ok := w.(http.Flusher); ok {
+		s.flush = f.Flush
+	}
+	return s
+}
+
+// WithStream returns a new context carrying the stream, enabling
+// out-of-order rendering of the Suspense nodes built with it.
+func WithStream(ctx context.Context, s *Stream) context.Context {
+	return context.WithValue(ctx, streamContextKey{}, s)
+}
+
+// StreamFromContext retrieves the stream from context, if set.
+func StreamFromContext(ctx context.Context) (*Stream, bool) {
+	s, ok := ctx.Value(streamContextKey{}).(*Stream)
+	return s, ok && s != nil
+}
+
+// Render writes node, then every suspended section as it resolves. It
+// returns once all sections are written, or when c is done. Sections
+// whose load failed keep their fallback and are reported in the error.
+//
+// Example:
+//
+//	s := html.NewStream(w)
+//	c := html.WithStream(r.Context(), s)
+//	if err := s.Render(c, page(c)); err != nil {
+//		log.Print(err)
+//	}
+func (s *Stream) Render(c context.Context, node Node) error {
+	if err := s.write(node()); err != nil {
+		return err
+	}
+
+	var errs []error
+	for s.remaining() > 0 {
+		select {
+		case <-c.Done():
+			return c.Err()
+		case r := <-s.results:
+			s.mu.Lock()
+			s.pending--
+			s.mu.Unlock()
+			if r.err != nil {
+				errs = append(errs, fmt.Errorf("wave: suspense %s: %w", r.id, r.err))
+				continue
+			}
+			// Resolved sections may suspend again; those are awaited too
+			if err := s.write(s.swap(c, r)); err != nil {
+				return err
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Stream) remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pending
+}
+
+func (s *Stream) write(chunk string) error {
+	if _, err := io.WriteString(s.w, chunk+"\n"); err != nil {
+		return err
+	}
+	s.flush()
+	return nil
+}
+
+// suspend starts loading a section and returns its placeholder id.
+func (s *Stream) suspend(c context.Context, load func(context.Context) (Node, error)) string {
+	s.mu.Lock()
+	s.count++
+	s.pending++
+	id := "wave-suspense-" + strconv.Itoa(s.count)
+	s.mu.Unlock()
+
+	// The load runs alongside the rest of the document, so it validates
+	// with a fork of its own rather than the shared element stack
+	result := suspenseResult{id: id}
+	lc := c
+	if v, ok := ValidatorFromContext(c); ok && v != nil {
+		result.ancestors = v.ancestors()
+		result.validator = v.Fork()
+		result.validator.stack = slices.Clone(result.ancestors)
+		lc = WithValidator(c, result.validator)
+	}
+
+	go func() {
+		result.node, result.err = load(lc)
+		select {
+		case s.results <- result:
+		case <-c.Done():
+		}
+	}()
+	return id
+}
+
+// swapFunction replaces everything between a section's start and end
+// markers with the content of its template.
+const swapFunction = `function $waveSwap(id){var s=document.getElementById(id),e=document.getElementById(id+"-end"),t=document.getElementById(id+"-content");if(s&&e){while(s.nextSibling&&s.nextSibling!==e)s.nextSibling.remove();if(t)e.before(t.content);s.remove();e.remove()}t&&t.remove()}`
+
+// swap renders a resolved section and the script moving it into place.
+// The swap function is only sent with the first section.
+func (s *Stream) swap(c context.Context, r suspenseResult) string {
+	script := `$waveSwap("` + r.id + `");`
+	if !s.swapReady {
+		script = swapFunction + script
+		s.swapReady = true
+	}
+	out := Script(c, nil, Text(script))()
+
+	// An empty section just removes its fallback
+	if r.node == nil {
+		return out
+	}
+
+	// Validate the section as if it rendered at its placeholder
+	var content string
+	if r.validator != nil {
+		content = r.validator.within(r.ancestors, r.node)
+	} else {
+		content = r.node()
+	}
+	if content == "" {
+		return out
+	}
+	return Template(c, Attrs{"id": r.id + "-content"}, Text(content))() + "\n" + out
+}
+
+// Suspense renders fallback immediately and streams the result of load
+// later, when rendered with a Stream in context; load runs concurrently
+// with the rest of the document. Without a Stream, it waits for load
+// and renders its result in place, or fallback if load fails, reporting
+// the error to the ErrorHandler in context.
+//
+// The fallback sits between two empty <template> markers, which are
+// valid anywhere, even in <ul>, <tbody> or <tr>; the fallback itself
+// must fit its spot, e.g. a Tr inside a Tbody. A nil fallback shows
+// nothing until the section arrives.
+//
+// Example:
+//
+//	html.Tbody(c, nil,
+//		html.Suspense(c, html.Tr(c, nil, html.Td(c, nil, html.Text("Loading orders…"))),
+//			func(c context.Context) (html.Node, error) {
+//				orders, err := db.Orders(c)
+//				if err != nil {
+//					return nil, err
+//				}
+//				return OrderRows(c, orders), nil
+//			}),
+//	)
+func Suspense(c context.Context, fallback Node, load func(context.Context) (Node, error)) Node {
+	return func() string {
+		s, ok := StreamFromContext(c)
+		if !ok {
+			node, err := load(c)
+			if err != nil {
+				reportError(c, fmt.Errorf("wave: suspense: %w", err))
+			}
+			if err != nil || node == nil {
+				node = fallback
+			}
+			if node == nil {
+				return ""
+			}
+			return node()
+		}
+
+		id := s.suspend(c, load)
+		parts := []string{Template(c, Attrs{"id": id, "data-wave-suspense": "start"})()}
+		if fallback != nil {
+			if out := fallback(); out != "" {
+				parts = append(parts, out)
+			}
+		}
+		parts = append(parts, Template(c, Attrs{"id": id + "-end", "data-wave-suspense": "end"})())
+		return strings.Join(parts, "\n")
+	}
+}
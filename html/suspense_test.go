@@ -0,0 +1,88 @@
+package html
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuspenseWithoutStream(t *testing.T) {
+	var reported []error
+	c := WithIDGenerator(context.Background(), func(string) string { return "" })
+	c = WithErrorHandler(c, func(err error) { reported = append(reported, err) })
+	errLoad := errors.New("db down")
+
+	got := Suspense(c, Text("fallback"), func(context.Context) (Node, error) {
+		return nil, errLoad
+	})()
+	if got != "fallback" {
+		t.Errorf("got %q, want the fallback", got)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], errLoad) {
+		t.Errorf("reported %v, want the load error", reported)
+	}
+
+	got = Suspense(c, Text("fallback"), func(context.Context) (Node, error) {
+		return Text("loaded"), nil
+	})()
+	if got != "loaded" || len(reported) != 1 {
+		t.Errorf("got %q with %d errors, want the loaded node and no new error", got, len(reported))
+	}
+}
+
+func TestSuspenseStreamValidation(t *testing.T) {
+	v := NewValidator(nil)
+	c := WithIDGenerator(context.Background(), SequentialIDs("wave"))
+	c = WithValidator(c, v)
+
+	var sb strings.Builder
+	s := NewStream(&sb)
+	c = WithStream(c, s)
+
+	var items []Node
+	for i := range 6 {
+		items = append(items, Suspense(c, Li(c, nil, Text("…")), func(c context.Context) (Node, error) {
+			time.Sleep(time.Duration(i%3) * time.Millisecond)
+			// Render while other sections are being swapped in
+			row := Li(c, nil, Span(c, nil, Text(strconv.Itoa(i))))()
+			return Text(row), nil
+		}))
+	}
+	page := Div(c, nil, Ul(c, nil, items...))
+	if err := s.Render(c, page); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := v.Violations(); len(got) != 0 {
+		t.Errorf("violations %v, want none", got)
+	}
+	for i := range 6 {
+		if !strings.Contains(sb.String(), "<span id=") || !strings.Contains(sb.String(), strconv.Itoa(i)) {
+			t.Fatalf("section %d missing from:\n%s", i, sb.String())
+		}
+	}
+}
+
+func TestSuspenseStreamReportsMisplacedSection(t *testing.T) {
+	v := NewValidator(nil)
+	c := WithValidator(context.Background(), v)
+
+	var sb strings.Builder
+	s := NewStream(&sb)
+	c = WithStream(c, s)
+
+	page := Ul(c, nil, Suspense(c, nil, func(c context.Context) (Node, error) {
+		return P(c, nil, Text("not a list item")), nil
+	}))
+	if err := s.Render(c, page); err != nil {
+		t.Fatal(err)
+	}
+
+	got := v.Violations()
+	if len(got) != 1 || got[0].Tag != "p" || got[0].Parent != "ul" {
+		t.Errorf("violations %v, want <p> inside <ul>", got)
+	}
+}
@@ -0,0 +1,499 @@
+package html
+
+import (
+	"slices"
+	"strings"
+)
+
+// -----------------------
+// Tailwind Class Merging
+// -----------------------
+
+// mergeTailwind resolves conflicts between Tailwind utility classes,
+// modelled on tailwind-merge: when two classes target the same CSS
+// property under the same variants (md:, hover:, ...) and importance,
+// the later one wins. Classes Wave does not recognise are always kept,
+// and exact duplicates are removed. Surviving classes keep their order.
+//
+// Example:
+//
+//	mergeTailwind("px-4 py-2 text-gray-800 hover:bg-blue-600", "p-2 text-lg hover:bg-red-600")
+//	// "text-gray-800 p-2 text-lg hover:bg-red-600"
+func mergeTailwind(classLists ...string) string {
+	var classes []string
+	for _, list := range classLists {
+		classes = append(classes, strings.Fields(list)...)
+	}
+
+	seen := map[string]bool{}
+	keep := make([]bool, len(classes))
+	for i := len(classes) - 1; i >= 0; i-- {
+		c := classes[i]
+		if seen["class:"+c] {
+			continue
+		}
+		seen["class:"+c] = true
+
+		modifier, group := parseTailwindClass(c)
+		if group == "" {
+			keep[i] = true
+			continue
+		}
+		if seen[modifier+group] {
+			continue
+		}
+		keep[i] = true
+		seen[modifier+group] = true
+		for _, conflict := range tailwindConflicts[group] {
+			seen[modifier+conflict] = true
+		}
+	}
+
+	merged := make([]string, 0, len(classes))
+	for i, c := range classes {
+		if keep[i] {
+			merged = append(merged, c)
+		}
+	}
+	return strings.Join(merged, " ")
+}
+
+// parseTailwindClass splits a class into a modifier key (variants and
+// importance) and the utility group it belongs to. The group is empty
+// for classes that are not recognised as Tailwind utilities.
+func parseTailwindClass(c string) (modifier, group string) {
+	variants, base := splitVariants(c)
+
+	important := false
+	if strings.HasPrefix(base, "!") {
+		important, base = true, base[1:]
+	} else if strings.HasSuffix(base, "!") {
+		important, base = true, base[:len(base)-1]
+	}
+
+	// Arbitrary variants like [&>*]: are order sensitive; others are not
+	if !slices.ContainsFunc(variants, func(v string) bool { return strings.HasPrefix(v, "[") }) {
+		slices.Sort(variants)
+	}
+	modifier = strings.Join(variants, ":") + "|"
+	if important {
+		modifier += "!"
+	}
+	modifier += "|"
+
+	return modifier, tailwindGroup(base)
+}
+
+// splitVariants splits "md:hover:bg-red-500" into its variants and the
+// base utility, ignoring colons inside arbitrary values.
+func splitVariants(c string) ([]string, string) {
+	var variants []string
+	depth, start := 0, 0
+	for i := 0; i < len(c); i++ {
+		switch c[i] {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case ':':
+			if depth == 0 {
+				variants = append(variants, c[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return variants, c[start:]
+}
+
+// tailwindGroup returns the utility group for a base class (without
+// variants or importance), or "" if it is not a known utility.
+func tailwindGroup(base string) string {
+	// Arbitrary properties: [mask-type:luminance]
+	if strings.HasPrefix(base, "[") && strings.HasSuffix(base, "]") {
+		if prop, _, ok := strings.Cut(base[1:len(base)-1], ":"); ok {
+			return "arbitrary-" + prop
+		}
+		return ""
+	}
+
+	// Negative values: -mt-4, -translate-x-2
+	base = strings.TrimPrefix(base, "-")
+
+	// Try the longest dash-separated prefix first: for "border-t-red-500"
+	// try "border-t-red-500", "border-t-red", "border-t", "border".
+	key := base
+	for {
+		if resolve, ok := tailwindRules[key]; ok {
+			value := strings.TrimPrefix(strings.TrimPrefix(base, key), "-")
+			return resolve(value)
+		}
+		i := lastDashOutsideBrackets(key)
+		if i <= 0 {
+			return ""
+		}
+		key = key[:i]
+	}
+}
+
+func lastDashOutsideBrackets(s string) int {
+	depth := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		switch s[i] {
+		case ']', ')':
+			depth++
+		case '[', '(':
+			depth--
+		case '-':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// -----------------------
+// Value Classification
+// -----------------------
+
+var (
+	tshirtSizes = tagSet("xs", "sm", "md", "lg", "xl", "2xl", "3xl", "4xl", "5xl", "6xl", "7xl", "8xl", "9xl")
+	fontWeights = tagSet("thin", "extralight", "light", "normal", "medium", "semibold", "bold", "extrabold", "black")
+	lineStyles  = tagSet("solid", "dashed", "dotted", "double", "hidden", "none")
+)
+
+// stripOpacity removes a trailing "/50" style modifier outside brackets.
+func stripOpacity(v string) string {
+	if i := strings.LastIndexByte(v, '/'); i > 0 && !strings.Contains(v[i:], "]") {
+		return v[:i]
+	}
+	return v
+}
+
+func isArbitrary(v string) bool {
+	return strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]")
+}
+
+// isNumber reports whether v is a plain number like "2" or "0.5".
+func isNumber(v string) bool {
+	if v == "" {
+		return false
+	}
+	for _, r := range v {
+		if (r < '0' || r > '9') && r != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// isArbitraryColor reports whether an arbitrary value like [#eee] or
+// [color:var(--x)] is a color rather than a length.
+func isArbitraryColor(v string) bool {
+	if !isArbitrary(v) {
+		return false
+	}
+	inner := v[1 : len(v)-1]
+	for _, prefix := range []string{"#", "rgb", "hsl", "oklch", "oklab", "lab(", "lch(", "hwb(", "color"} {
+		if strings.HasPrefix(inner, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLength reports whether v is a width-like value: a number, "px",
+// or an arbitrary value that is not a color.
+func isLength(v string) bool {
+	return isNumber(v) || v == "px" || (isArbitrary(v) && !isArbitraryColor(v))
+}
+
+// -----------------------
+// Rules
+// -----------------------
+
+type groupResolver func(value string) string
+
+// fixed resolves every value to the same group.
+func fixed(group string) groupResolver {
+	return func(string) string { return group }
+}
+
+// byValue resolves known values to their group and anything else to fallback.
+func byValue(values map[string]string, fallback string) groupResolver {
+	return func(v string) string {
+		if g, ok := values[v]; ok {
+			return g
+		}
+		return fallback
+	}
+}
+
+// widthOrColor resolves "", numbers and lengths to width, styles to
+// style and anything else to color, as used by border-*, ring-*, ...
+func widthOrColor(width, style, color string) groupResolver {
+	return func(v string) string {
+		v = stripOpacity(v)
+		switch {
+		case v == "" || isLength(v):
+			return width
+		case style != "" && lineStyles[v]:
+			return style
+		default:
+			return color
+		}
+	}
+}
+
+var tailwindRules = map[string]groupResolver{
+	// Layout
+	"block": fixed("display"), "inline": fixed("display"), "contents": fixed("display"),
+	"flow-root": fixed("display"), "hidden": fixed("display"), "list-item": fixed("display"),
+	"grid": fixed("display"), "inline-grid": fixed("display"),
+	"flex": byValue(map[string]string{
+		"": "display", "row": "flex-direction", "row-reverse": "flex-direction",
+		"col": "flex-direction", "col-reverse": "flex-direction",
+		"wrap": "flex-wrap", "wrap-reverse": "flex-wrap", "nowrap": "flex-wrap",
+	}, "flex"),
+	"table":  byValue(map[string]string{"auto": "table-layout", "fixed": "table-layout"}, "display"),
+	"static": fixed("position"), "fixed": fixed("position"), "absolute": fixed("position"),
+	"relative": fixed("position"), "sticky": fixed("position"),
+	"visible": fixed("visibility"), "invisible": fixed("visibility"), "collapse": fixed("visibility"),
+	"container": fixed("container"), "sr-only": fixed("sr"), "not-sr-only": fixed("sr"),
+	"isolate": fixed("isolation"), "isolation": fixed("isolation"),
+	"box":   byValue(map[string]string{"decoration-slice": "box-decoration", "decoration-clone": "box-decoration"}, "box-sizing"),
+	"float": fixed("float"), "clear": fixed("clear"), "object": byValue(map[string]string{
+		"contain": "object-fit", "cover": "object-fit", "fill": "object-fit", "none": "object-fit", "scale-down": "object-fit",
+	}, "object-position"),
+	"overflow": fixed("overflow"), "overflow-x": fixed("overflow-x"), "overflow-y": fixed("overflow-y"),
+	"overscroll": fixed("overscroll"), "overscroll-x": fixed("overscroll-x"), "overscroll-y": fixed("overscroll-y"),
+	"inset": fixed("inset"), "inset-x": fixed("inset-x"), "inset-y": fixed("inset-y"),
+	"top": fixed("top"), "right": fixed("right"), "bottom": fixed("bottom"), "left": fixed("left"),
+	"start": fixed("start"), "end": fixed("end"), "z": fixed("z"),
+	"aspect": fixed("aspect"), "columns": fixed("columns"),
+
+	// Flexbox & Grid
+	"basis": fixed("basis"), "grow": fixed("grow"), "shrink": fixed("shrink"), "order": fixed("order"),
+	"grid-cols": fixed("grid-cols"), "grid-rows": fixed("grid-rows"), "grid-flow": fixed("grid-flow"),
+	"col": fixed("col-start-end"), "col-span": fixed("col-start-end"), "col-start": fixed("col-start"), "col-end": fixed("col-end"),
+	"row": fixed("row-start-end"), "row-span": fixed("row-start-end"), "row-start": fixed("row-start"), "row-end": fixed("row-end"),
+	"auto-cols": fixed("auto-cols"), "auto-rows": fixed("auto-rows"),
+	"gap": fixed("gap"), "gap-x": fixed("gap-x"), "gap-y": fixed("gap-y"),
+	"justify": fixed("justify-content"), "justify-items": fixed("justify-items"), "justify-self": fixed("justify-self"),
+	"content": byValue(map[string]string{"none": "content"}, "align-content"),
+	"items":   fixed("align-items"), "self": fixed("align-self"),
+	"place-content": fixed("place-content"), "place-items": fixed("place-items"), "place-self": fixed("place-self"),
+
+	// Spacing
+	"p": fixed("p"), "px": fixed("px"), "py": fixed("py"), "ps": fixed("ps"), "pe": fixed("pe"),
+	"pt": fixed("pt"), "pr": fixed("pr"), "pb": fixed("pb"), "pl": fixed("pl"),
+	"m": fixed("m"), "mx": fixed("mx"), "my": fixed("my"), "ms": fixed("ms"), "me": fixed("me"),
+	"mt": fixed("mt"), "mr": fixed("mr"), "mb": fixed("mb"), "ml": fixed("ml"),
+	"space-x": byValue(map[string]string{"reverse": "space-x-reverse"}, "space-x"),
+	"space-y": byValue(map[string]string{"reverse": "space-y-reverse"}, "space-y"),
+
+	// Sizing
+	"w": fixed("w"), "min-w": fixed("min-w"), "max-w": fixed("max-w"),
+	"h": fixed("h"), "min-h": fixed("min-h"), "max-h": fixed("max-h"), "size": fixed("size"),
+
+	// Typography
+	"text": func(v string) string {
+		base := stripOpacity(v)
+		switch {
+		case base == "base" || tshirtSizes[base] || (isArbitrary(base) && !isArbitraryColor(base)):
+			return "font-size"
+		case base == "left" || base == "center" || base == "right" || base == "justify" || base == "start" || base == "end":
+			return "text-align"
+		case base == "wrap" || base == "nowrap" || base == "balance" || base == "pretty":
+			return "text-wrap"
+		case base == "ellipsis" || base == "clip":
+			return "text-overflow"
+		default:
+			return "text-color"
+		}
+	},
+	"font": func(v string) string {
+		if fontWeights[v] || (isArbitrary(v) && isNumber(v[1:len(v)-1])) {
+			return "font-weight"
+		}
+		return "font-family"
+	},
+	"italic": fixed("font-style"), "not-italic": fixed("font-style"),
+	"antialiased": fixed("font-smoothing"), "subpixel-antialiased": fixed("font-smoothing"),
+	"tracking": fixed("tracking"), "leading": fixed("leading"), "line-clamp": fixed("line-clamp"),
+	"list":       byValue(map[string]string{"inside": "list-style-position", "outside": "list-style-position"}, "list-style-type"),
+	"list-image": fixed("list-image"),
+	"underline":  fixed("text-decoration"), "overline": fixed("text-decoration"),
+	"line-through": fixed("text-decoration"), "no-underline": fixed("text-decoration"),
+	"underline-offset": fixed("underline-offset"),
+	"decoration": func(v string) string {
+		switch {
+		case v == "auto" || v == "from-font" || isLength(v):
+			return "decoration-thickness"
+		case v == "solid" || v == "double" || v == "dotted" || v == "dashed" || v == "wavy":
+			return "decoration-style"
+		default:
+			return "decoration-color"
+		}
+	},
+	"uppercase": fixed("text-transform"), "lowercase": fixed("text-transform"),
+	"capitalize": fixed("text-transform"), "normal-case": fixed("text-transform"),
+	"truncate": fixed("text-overflow"), "indent": fixed("indent"), "align": fixed("vertical-align"),
+	"whitespace": fixed("whitespace"), "hyphens": fixed("hyphens"),
+	"break":       fixed("word-break"),
+	"break-after": fixed("break-after"), "break-before": fixed("break-before"), "break-inside": fixed("break-inside"),
+
+	// Backgrounds
+	"bg": func(v string) string {
+		base := stripOpacity(v)
+		switch {
+		case base == "fixed" || base == "local" || base == "scroll":
+			return "bg-attachment"
+		case base == "auto" || base == "cover" || base == "contain":
+			return "bg-size"
+		case base == "none" || strings.HasPrefix(base, "gradient") || strings.HasPrefix(base, "linear") ||
+			strings.HasPrefix(base, "radial") || strings.HasPrefix(base, "conic") || strings.HasPrefix(base, "[url("):
+			return "bg-image"
+		case strings.HasPrefix(base, "repeat") || base == "no-repeat":
+			return "bg-repeat"
+		case strings.HasPrefix(base, "clip"):
+			return "bg-clip"
+		case strings.HasPrefix(base, "origin"):
+			return "bg-origin"
+		case base == "top" || base == "bottom" || base == "left" || base == "right" || base == "center" ||
+			strings.HasPrefix(base, "left-") || strings.HasPrefix(base, "right-"):
+			return "bg-position"
+		default:
+			return "bg-color"
+		}
+	},
+	"from": fixed("gradient-from"), "via": fixed("gradient-via"), "to": fixed("gradient-to"),
+
+	// Borders
+	"rounded":   fixed("rounded"),
+	"rounded-s": fixed("rounded-s"), "rounded-e": fixed("rounded-e"),
+	"rounded-t": fixed("rounded-t"), "rounded-r": fixed("rounded-r"),
+	"rounded-b": fixed("rounded-b"), "rounded-l": fixed("rounded-l"),
+	"rounded-ss": fixed("rounded-ss"), "rounded-se": fixed("rounded-se"),
+	"rounded-ee": fixed("rounded-ee"), "rounded-es": fixed("rounded-es"),
+	"rounded-tl": fixed("rounded-tl"), "rounded-tr": fixed("rounded-tr"),
+	"rounded-br": fixed("rounded-br"), "rounded-bl": fixed("rounded-bl"),
+	"border": func(v string) string {
+		switch v {
+		case "collapse", "separate":
+			return "border-collapse"
+		}
+		return widthOrColor("border-w", "border-style", "border-color")(v)
+	},
+	"border-x":       widthOrColor("border-w-x", "", "border-color-x"),
+	"border-y":       widthOrColor("border-w-y", "", "border-color-y"),
+	"border-s":       widthOrColor("border-w-s", "", "border-color-s"),
+	"border-e":       widthOrColor("border-w-e", "", "border-color-e"),
+	"border-t":       widthOrColor("border-w-t", "", "border-color-t"),
+	"border-r":       widthOrColor("border-w-r", "", "border-color-r"),
+	"border-b":       widthOrColor("border-w-b", "", "border-color-b"),
+	"border-l":       widthOrColor("border-w-l", "", "border-color-l"),
+	"border-spacing": fixed("border-spacing"),
+	"divide-x":       byValue(map[string]string{"reverse": "divide-x-reverse"}, "divide-x"),
+	"divide-y":       byValue(map[string]string{"reverse": "divide-y-reverse"}, "divide-y"),
+	"divide":         widthOrColor("divide-w", "divide-style", "divide-color"),
+	"outline": func(v string) string {
+		if v == "hidden" {
+			return "outline-style"
+		}
+		return widthOrColor("outline-w", "outline-style", "outline-color")(v)
+	},
+	"outline-offset": fixed("outline-offset"),
+	"ring": func(v string) string {
+		if v == "inset" {
+			return "ring-inset"
+		}
+		return widthOrColor("ring-w", "", "ring-color")(v)
+	},
+	"ring-offset": widthOrColor("ring-offset-w", "", "ring-offset-color"),
+
+	// Effects
+	"shadow": func(v string) string {
+		base := stripOpacity(v)
+		if base == "" || base == "inner" || base == "none" || tshirtSizes[base] || (isArbitrary(base) && !isArbitraryColor(base)) {
+			return "shadow"
+		}
+		return "shadow-color"
+	},
+	"opacity": fixed("opacity"), "mix-blend": fixed("mix-blend"), "bg-blend": fixed("bg-blend"),
+
+	// Filters
+	"blur": fixed("blur"), "brightness": fixed("brightness"), "contrast": fixed("contrast"),
+	"drop-shadow": fixed("drop-shadow"), "grayscale": fixed("grayscale"), "hue-rotate": fixed("hue-rotate"),
+	"invert": fixed("invert"), "saturate": fixed("saturate"), "sepia": fixed("sepia"),
+	"backdrop-blur": fixed("backdrop-blur"), "backdrop-brightness": fixed("backdrop-brightness"),
+	"backdrop-opacity": fixed("backdrop-opacity"), "backdrop-saturate": fixed("backdrop-saturate"),
+
+	// Transitions & Animation
+	"transition": fixed("transition"), "duration": fixed("duration"), "ease": fixed("ease"),
+	"delay": fixed("delay"), "animate": fixed("animate"),
+
+	// Transforms
+	"transform": fixed("transform"), "origin": fixed("origin"), "scale": fixed("scale"),
+	"scale-x": fixed("scale-x"), "scale-y": fixed("scale-y"), "rotate": fixed("rotate"),
+	"translate-x": fixed("translate-x"), "translate-y": fixed("translate-y"),
+	"skew-x": fixed("skew-x"), "skew-y": fixed("skew-y"),
+
+	// Interactivity
+	"cursor": fixed("cursor"), "select": fixed("select"), "resize": fixed("resize"),
+	"pointer-events": fixed("pointer-events"), "appearance": fixed("appearance"),
+	"accent": fixed("accent"), "caret": fixed("caret"), "scroll": fixed("scroll-behavior"),
+	"scroll-m": fixed("scroll-m"), "scroll-mx": fixed("scroll-mx"), "scroll-my": fixed("scroll-my"),
+	"scroll-mt": fixed("scroll-mt"), "scroll-mr": fixed("scroll-mr"), "scroll-mb": fixed("scroll-mb"), "scroll-ml": fixed("scroll-ml"),
+	"scroll-p": fixed("scroll-p"), "scroll-px": fixed("scroll-px"), "scroll-py": fixed("scroll-py"),
+	"scroll-pt": fixed("scroll-pt"), "scroll-pr": fixed("scroll-pr"), "scroll-pb": fixed("scroll-pb"), "scroll-pl": fixed("scroll-pl"),
+	"snap": fixed("snap"), "touch": fixed("touch"), "will-change": fixed("will-change"),
+
+	// SVG
+	"fill": fixed("fill"),
+	"stroke": func(v string) string {
+		if isLength(v) {
+			return "stroke-w"
+		}
+		return "stroke-color"
+	},
+}
+
+// tailwindConflicts lists, per group, the groups a class of that
+// group overrides: a later "p-2" removes an earlier "px-4", but a
+// later "px-4" keeps an earlier "p-2" since it only refines it.
+var tailwindConflicts = map[string][]string{
+	"overflow":   {"overflow-x", "overflow-y"},
+	"overscroll": {"overscroll-x", "overscroll-y"},
+	"inset":      {"inset-x", "inset-y", "start", "end", "top", "right", "bottom", "left"},
+	"inset-x":    {"right", "left"},
+	"inset-y":    {"top", "bottom"},
+	"gap":        {"gap-x", "gap-y"},
+	"p":          {"px", "py", "ps", "pe", "pt", "pr", "pb", "pl"},
+	"px":         {"pr", "pl"},
+	"py":         {"pt", "pb"},
+	"m":          {"mx", "my", "ms", "me", "mt", "mr", "mb", "ml"},
+	"mx":         {"mr", "ml"},
+	"my":         {"mt", "mb"},
+	"size":       {"w", "h"},
+	"font-size":  {"leading"},
+	"rounded": {"rounded-s", "rounded-e", "rounded-t", "rounded-r", "rounded-b", "rounded-l",
+		"rounded-ss", "rounded-se", "rounded-ee", "rounded-es",
+		"rounded-tl", "rounded-tr", "rounded-br", "rounded-bl"},
+	"rounded-s": {"rounded-ss", "rounded-es"},
+	"rounded-e": {"rounded-se", "rounded-ee"},
+	"rounded-t": {"rounded-tl", "rounded-tr"},
+	"rounded-r": {"rounded-tr", "rounded-br"},
+	"rounded-b": {"rounded-br", "rounded-bl"},
+	"rounded-l": {"rounded-tl", "rounded-bl"},
+	"border-w": {"border-w-x", "border-w-y", "border-w-s", "border-w-e",
+		"border-w-t", "border-w-r", "border-w-b", "border-w-l"},
+	"border-w-x": {"border-w-r", "border-w-l"},
+	"border-w-y": {"border-w-t", "border-w-b"},
+	"border-color": {"border-color-x", "border-color-y", "border-color-s", "border-color-e",
+		"border-color-t", "border-color-r", "border-color-b", "border-color-l"},
+	"border-color-x": {"border-color-r", "border-color-l"},
+	"border-color-y": {"border-color-t", "border-color-b"},
+	"col-start-end":  {"col-start", "col-end"},
+	"row-start-end":  {"row-start", "row-end"},
+}
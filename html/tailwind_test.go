@@ -0,0 +1,69 @@
+package html
+
+import "testing"
+
+func TestMergeTailwind(t *testing.T) {
+	tests := []struct {
+		name        string
+		theme, user string
+		want        string
+	}{
+		// Different properties of the same prefix
+		{"font size vs color", "text-lg", "text-gray-800", "text-lg text-gray-800"},
+		{"color vs font size", "text-gray-800", "text-lg", "text-gray-800 text-lg"},
+		{"font size replaced", "text-sm text-gray-800", "text-lg", "text-gray-800 text-lg"},
+		{"color replaced", "text-lg text-gray-800", "text-blue-600", "text-lg text-blue-600"},
+		{"arbitrary color", "text-gray-800", "text-[#1d4ed8]", "text-[#1d4ed8]"},
+		{"arbitrary size", "text-sm", "text-[13px]", "text-[13px]"},
+		{"text alignment", "text-left text-sm", "text-center", "text-sm text-center"},
+		{"border width vs color", "border border-gray-300", "border-2", "border-gray-300 border-2"},
+		{"border color", "border border-gray-300", "border-red-500", "border border-red-500"},
+
+		// Shorthands and refinements
+		{"padding shorthand wins", "px-4 py-2", "p-2", "p-2"},
+		{"padding refinement kept", "p-2", "px-4", "p-2 px-4"},
+		{"padding side", "px-4", "pl-2", "px-4 pl-2"},
+		{"axis overrides side", "pl-2", "px-4", "px-4"},
+		{"margin negative", "mt-4", "-mt-2", "-mt-2"},
+		{"size overrides width", "w-4 h-4", "size-8", "size-8"},
+		{"rounded corner", "rounded", "rounded-t-lg", "rounded rounded-t-lg"},
+
+		// Variants
+		{"hover", "hover:bg-blue-600", "hover:bg-red-600", "hover:bg-red-600"},
+		{"hover kept next to base", "bg-blue-600", "hover:bg-red-600", "bg-blue-600 hover:bg-red-600"},
+		{"responsive", "md:px-4", "md:px-8", "md:px-8"},
+		{"responsive kept next to base", "px-4", "md:px-8", "px-4 md:px-8"},
+		{"variant order does not matter", "md:hover:bg-blue-600", "hover:md:bg-red-600", "hover:md:bg-red-600"},
+		{"different variants", "md:hover:bg-blue-600", "hover:bg-red-600", "md:hover:bg-blue-600 hover:bg-red-600"},
+		{"arbitrary variant", "[&>*]:p-2", "[&>*]:p-4", "[&>*]:p-4"},
+
+		// Arbitrary values and properties
+		{"arbitrary width", "w-full", "w-[37px]", "w-[37px]"},
+		{"arbitrary width replaced", "w-[37px]", "w-1/2", "w-1/2"},
+		{"arbitrary value with colon", "bg-[url(a:b)]", "bg-red-500", "bg-[url(a:b)] bg-red-500"},
+		{"arbitrary property", "[mask-type:luminance]", "[mask-type:alpha]", "[mask-type:alpha]"},
+
+		// Importance
+		{"important prefix", "!p-2", "!p-4", "!p-4"},
+		{"important kept next to normal", "!p-2", "p-4", "!p-2 p-4"},
+		{"important suffix", "p-2!", "!p-4", "!p-4"},
+
+		// Line height modifier
+		{"font size with line height", "text-sm/6", "text-lg", "text-lg"},
+		{"font size overrides leading", "leading-7", "text-sm/6", "text-sm/6"},
+		{"leading refines font size", "text-sm/6", "leading-7", "text-sm/6 leading-7"},
+		{"color with opacity", "text-gray-800", "text-black/50", "text-black/50"},
+
+		// Unknown and duplicate classes
+		{"unknown kept", "card title", "card-lg", "card title card-lg"},
+		{"duplicates removed", "btn p-2", "btn p-2", "btn p-2"},
+		{"empty user", "p-2 text-sm", "", "p-2 text-sm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TailwindClassMerge(tt.theme, tt.user); got != tt.want {
+				t.Errorf("TailwindClassMerge(%q, %q) = %q, want %q", tt.theme, tt.user, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,42 +2,403 @@ package html
 
 import (
 	"context"
+	"sort"
+	"strings"
 )
 
 // unexported key type ensures uniqueness
 type themeContextKey struct{}
 
-// Theme maps an HTML tag name (e.g. "p", "h1", "button")
+// Theme maps an HTML tag name (e.g. "p", "h1", "button"),
+// optionally refined by attributes (e.g. "input[type=checkbox]"),
 // to a set of default attributes (Attrs) that define its
 // styling and behavior. These defaults can be applied
 // automatically when rendering elements, while still
 // allowing user-specified attributes to override them.
 //
+// Themes can extend a base theme, be merged together,
+// define named variants selected per element with Variant
+// and color schemes such as dark mode (see WithScheme).
+//
 // Example:
 //
-//	Theme{
+//	NewTheme(map[string]Attrs{
 //	  "p": AttrClass("mb-4 text-base text-gray-800"),
 //	  "a": AttrClass("text-blue-600 hover:underline"),
-//	}
-type Theme map[string]Attrs
+//	}).WithVariant("danger", map[string]Attrs{
+//	  "button": AttrClass("bg-red-600 hover:bg-red-700"),
+//	})
+type Theme struct {
+	// Elements maps a selector (see selector) to its default attributes.
+	Elements map[string]Attrs
+
+	// Variants maps a variant name to per-tag attributes that are
+	// layered on top of Elements for elements marked with Variant(name).
+	Variants map[string]map[string]Attrs
+
+	// ClassMerge combines theme and user classes.
+	// When nil, TailwindClassMerge is used.
+	ClassMerge ClassMerger
+
+	// AttrMerge overrides how other attributes are combined, keyed by
+	// attribute name. A nil entry makes the user value override.
+	// See defaultAttrMergers for the rules used otherwise.
+	AttrMerge map[string]AttrMerger
+
+	// Schemes maps a color scheme name (e.g. "dark") to per-tag
+	// attributes for that scheme; see SchemeStrategy.
+	Schemes map[string]map[string]Attrs
+
+	// SchemeStrategy decides how Schemes are rendered.
+	SchemeStrategy SchemeStrategy
+}
+
+// themeValue is what WithTheme stores: the theme and its parsed rules.
+type themeValue struct {
+	theme *Theme
+	rules *themeRules
+}
 
-// WithTheme returns a new context carrying the given theme.
+// WithTheme returns a new context carrying the given theme. The theme's
+// selectors are parsed here, once, rather than for every element, so
+// changes made to its maps afterwards are not seen by this context;
+// derive a new theme with Extend or MergeThemes instead.
 func WithTheme(ctx context.Context, theme *Theme) context.Context {
-	return context.WithValue(ctx, themeContextKey{}, theme)
+	v := themeValue{theme: theme}
+	if theme != nil {
+		v.rules = compileTheme(theme)
+	}
+	return context.WithValue(ctx, themeContextKey{}, v)
 }
 
 // ThemeFromContext retrieves the theme from context, if set.
 func ThemeFromContext(ctx context.Context) (*Theme, bool) {
-	t, ok := ctx.Value(themeContextKey{}).(*Theme)
-	return t, ok
+	v, ok := ctx.Value(themeContextKey{}).(themeValue)
+	return v.theme, ok
+}
+
+// themeRulesFromContext retrieves the parsed rules of the theme in
+// context, or nil.
+func themeRulesFromContext(ctx context.Context) *themeRules {
+	v, _ := ctx.Value(themeContextKey{}).(themeValue)
+	return v.rules
+}
+
+// WithoutTheme returns a new context with theming disabled, for subtrees
+// such as embedded third-party widgets or emails. Use WithTheme on the
+// returned context to swap in a different theme instead.
+func WithoutTheme(ctx context.Context) context.Context {
+	return WithTheme(ctx, nil)
 }
 
 // NewTheme creates a Theme from a given map of element styles.
 func NewTheme(elementStyle map[string]Attrs) *Theme {
-	t := Theme(elementStyle)
-	return &t
+	return &Theme{
+		Elements: elementStyle,
+		Variants: map[string]map[string]Attrs{},
+		Schemes:  map[string]map[string]Attrs{},
+	}
+}
+
+// Extend returns a new Theme based on t, with elementStyle merged on top.
+// Classes are merged with the theme's ClassMerge, other attributes are overridden.
+// The receiver is left untouched, so one base can back several themes.
+func (t *Theme) Extend(elementStyle map[string]Attrs) *Theme {
+	return MergeThemes(t, NewTheme(elementStyle))
+}
+
+// WithVariant returns a new Theme based on t with the named variant added.
+// If the variant already exists, elementStyle is merged on top of it.
+func (t *Theme) WithVariant(name string, elementStyle map[string]Attrs) *Theme {
+	return MergeThemes(t, &Theme{
+		Variants: map[string]map[string]Attrs{name: elementStyle},
+	})
+}
+
+// WithClassMerge returns a new Theme based on t that combines
+// classes with the given strategy.
+func (t *Theme) WithClassMerge(merge ClassMerger) *Theme {
+	return MergeThemes(t, &Theme{ClassMerge: merge})
+}
+
+// WithAttrMerge returns a new Theme based on t that combines the
+// named attribute with the given strategy.
+func (t *Theme) WithAttrMerge(name string, merge AttrMerger) *Theme {
+	return MergeThemes(t, &Theme{AttrMerge: map[string]AttrMerger{name: merge}})
+}
+
+// MergeThemes merges themes left to right into a new Theme.
+// Later themes override earlier ones per tag and per variant,
+// and the last ClassMerge, AttrMerge entries and SchemeStrategy set win;
+// themes leaving SchemeStrategy at SchemeInherit keep the one before.
+func MergeThemes(themes ...*Theme) *Theme {
+	merged := NewTheme(map[string]Attrs{})
+	for _, t := range themes {
+		if t == nil {
+			continue
+		}
+		if t.ClassMerge != nil {
+			merged.ClassMerge = t.ClassMerge
+		}
+		if t.SchemeStrategy != SchemeInherit {
+			merged.SchemeStrategy = t.SchemeStrategy
+		}
+		for name, merge := range t.AttrMerge {
+			if merged.AttrMerge == nil {
+				merged.AttrMerge = map[string]AttrMerger{}
+			}
+			merged.AttrMerge[name] = merge
+		}
+	}
+	for _, t := range themes {
+		if t == nil {
+			continue
+		}
+		merged.mergeElementStyles(merged.Elements, t.Elements)
+		for name, styles := range t.Variants {
+			if merged.Variants[name] == nil {
+				merged.Variants[name] = map[string]Attrs{}
+			}
+			merged.mergeElementStyles(merged.Variants[name], styles)
+		}
+		for name, styles := range t.Schemes {
+			if merged.Schemes[name] == nil {
+				merged.Schemes[name] = map[string]Attrs{}
+			}
+			merged.mergeElementStyles(merged.Schemes[name], styles)
+		}
+	}
+	return merged
+}
+
+func (t *Theme) mergeElementStyles(dst, src map[string]Attrs) {
+	for tag, attrs := range src {
+		dst[tag] = t.mergeAttrs(dst[tag], attrs)
+	}
+}
+
+// mergeAttrs merges theme attrs with user attrs using the theme's
+// merging strategies.
+func (t *Theme) mergeAttrs(themeAttrs, userAttrs Attrs) Attrs {
+	return mergeAttrsWith(t.attrMerger, themeAttrs, userAttrs)
+}
+
+// attrMerger returns how the theme combines the named attribute.
+func (t *Theme) attrMerger(name string) AttrMerger {
+	if name == "class" && t.ClassMerge != nil {
+		return AttrMerger(t.ClassMerge)
+	}
+	if merge, ok := t.AttrMerge[name]; ok {
+		return merge
+	}
+	return defaultAttrMerger(name)
+}
+
+// themeRules holds the selectors of a theme parsed and grouped by tag,
+// so an element is only matched against the rules that can apply.
+type themeRules struct {
+	theme       *Theme
+	elements    ruleSet
+	variants    map[string]ruleSet
+	schemes     map[string]ruleSet
+	schemeNames []string
+}
+
+func compileTheme(t *Theme) *themeRules {
+	r := &themeRules{
+		theme:       t,
+		elements:    newRuleSet(t.Elements),
+		variants:    make(map[string]ruleSet, len(t.Variants)),
+		schemes:     make(map[string]ruleSet, len(t.Schemes)),
+		schemeNames: t.SchemeNames(),
+	}
+	for name, rules := range t.Variants {
+		r.variants[name] = newRuleSet(rules)
+	}
+	for name, rules := range t.Schemes {
+		r.schemes[name] = newRuleSet(rules)
+	}
+	return r
+}
+
+// attrsFor resolves the theme attributes for an element.
+// Element rules apply first, then rules of the variant selected
+// in attrs (if any), then color scheme rules (see SchemeStrategy);
+// within each layer, less specific selectors apply before more
+// specific ones.
+func (r *themeRules) attrsFor(tag string, attrs Attrs, scheme string) (Attrs, bool) {
+	themed, ok := r.applyRules(nil, false, r.elements, tag, attrs)
+	if name := attrs[variantAttr]; name != "" {
+		themed, ok = r.applyRules(themed, ok, r.variants[name], tag, attrs)
+	}
+	return r.applySchemes(themed, ok, tag, attrs, scheme)
+}
+
+func (r *themeRules) applyRules(themed Attrs, ok bool, rules ruleSet, tag string, attrs Attrs) (Attrs, bool) {
+	for _, rule := range rules.matching(tag, attrs) {
+		themed, ok = r.theme.mergeAttrs(themed, rule), true
+	}
+	return themed, ok
+}
+
+// noThemeAttr marks an element that opts out of theming. It is
+// stripped before rendering.
+const noThemeAttr = "wave-notheme"
+
+// NoTheme opts a single element out of the theme in its context.
+//
+// Example:
+//
+//	Button(c, Attributes(NoTheme(), AttrClass("widget-btn")), Text("Go"))
+func NoTheme() Attrs { return Attrs{noThemeAttr: "true"} }
+
+// -----------------------
+// Selectors
+// -----------------------
+
+// A theme key is a selector: a tag name optionally followed by
+// attribute conditions, or attribute conditions alone.
+//
+//	"input"                     every <input>
+//	"input[type=checkbox]"      checkbox inputs
+//	"button[type=submit]"       submit buttons
+//	"[role=tab]"                any element with role="tab"
+//	"a[target]"                 links with a target attribute
+//
+// Precedence follows CSS specificity: more attribute conditions win,
+// then a tag over no tag; ties are broken by key for stable output.
+type selector struct {
+	key   string
+	tag   string
+	conds []selectorCond
+}
+
+type selectorCond struct {
+	name     string
+	value    string
+	hasValue bool
+}
+
+func (s selector) specificity() int {
+	n := len(s.conds) * 2
+	if s.tag != "" {
+		n++
+	}
+	return n
+}
+
+func (s selector) matches(tag string, attrs Attrs) bool {
+	if s.tag != "" && s.tag != tag {
+		return false
+	}
+	for _, cond := range s.conds {
+		v, ok := attrs[cond.name]
+		if !ok || (cond.hasValue && v != cond.value) {
+			return false
+		}
+	}
+	return true
 }
 
+// parseSelector parses a theme key. Malformed keys match nothing.
+func parseSelector(key string) (selector, bool) {
+	sel := selector{key: key}
+	i := strings.IndexByte(key, '[')
+	if i < 0 {
+		sel.tag = key
+		return sel, key != ""
+	}
+	sel.tag = key[:i]
+
+	rest := key[i:]
+	for rest != "" {
+		if rest[0] != '[' {
+			return selector{}, false
+		}
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			return selector{}, false
+		}
+		body := rest[1:end]
+		rest = rest[end+1:]
+
+		var cond selectorCond
+		if name, value, found := strings.Cut(body, "="); found {
+			cond = selectorCond{
+				name:     strings.TrimSpace(name),
+				value:    strings.Trim(strings.TrimSpace(value), `"'`),
+				hasValue: true,
+			}
+		} else {
+			cond = selectorCond{name: strings.TrimSpace(body)}
+		}
+		if cond.name == "" {
+			return selector{}, false
+		}
+		sel.conds = append(sel.conds, cond)
+	}
+	return sel, true
+}
+
+// ruleSet is a map of theme rules with its selectors parsed.
+type ruleSet struct {
+	rules  map[string]Attrs
+	byTag  map[string][]selector // selectors naming a tag
+	anyTag []selector            // selectors with attribute conditions only
+}
+
+// newRuleSet parses the keys of rules. Malformed keys are left out.
+func newRuleSet(rules map[string]Attrs) ruleSet {
+	rs := ruleSet{rules: rules, byTag: map[string][]selector{}}
+	for key := range rules {
+		sel, ok := parseSelector(key)
+		switch {
+		case !ok:
+		case sel.tag == "":
+			rs.anyTag = append(rs.anyTag, sel)
+		default:
+			rs.byTag[sel.tag] = append(rs.byTag[sel.tag], sel)
+		}
+	}
+	return rs
+}
+
+// matching returns the rules matching the element, ordered by
+// ascending precedence so that later rules override earlier ones.
+func (rs ruleSet) matching(tag string, attrs Attrs) []Attrs {
+	var matched []selector
+	for _, candidates := range [][]selector{rs.byTag[tag], rs.anyTag} {
+		for _, sel := range candidates {
+			if sel.matches(tag, attrs) {
+				matched = append(matched, sel)
+			}
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := matched[i].specificity(), matched[j].specificity()
+		if a != b {
+			return a < b
+		}
+		return matched[i].key < matched[j].key
+	})
+
+	out := make([]Attrs, len(matched))
+	for i, sel := range matched {
+		out[i] = rs.rules[sel.key]
+	}
+	return out
+}
+
+// variantAttr is the attribute that carries the selected variant.
+const variantAttr = "data-variant"
+
+// Variant selects a named theme variant for an element.
+//
+// Example:
+//
+//	Button(c, Attributes(Variant("danger")), Text("Delete"))
+func Variant(name string) Attrs { return Attrs{variantAttr: name} }
+
 // NewDefaultTheme returns a Theme with sensible defaults for major HTML elements.
 func NewDefaultTheme() *Theme {
 	t := map[string]Attrs{
@@ -59,8 +420,9 @@ func NewDefaultTheme() *Theme {
 		"a": AttrClass("text-blue-500 hover:underline"),
 
 		// Buttons
-		"button": AttrClass("px-4 py-2 rounded bg-blue-500 text-white hover:bg-blue-600 disabled:opacity-50 disabled:cursor-not-allowed"),
-		"submit": AttrClass("px-4 py-2 rounded bg-green-500 text-white hover:bg-green-600"),
+		"button":              AttrClass("px-4 py-2 rounded bg-blue-500 text-white hover:bg-blue-600 disabled:opacity-50 disabled:cursor-not-allowed"),
+		"button[type=submit]": AttrClass("px-4 py-2 rounded bg-green-500 text-white hover:bg-green-600"),
+		"input[type=submit]":  AttrClass("px-4 py-2 rounded bg-green-500 text-white hover:bg-green-600 w-auto border-0"),
 
 		// Forms
 		"form":     AttrClass("space-y-4"),
@@ -69,6 +431,10 @@ func NewDefaultTheme() *Theme {
 		"select":   AttrClass("border border-gray-300 mb-2 px-3 py-2 rounded w-full focus:outline-none focus:ring-2 focus:ring-blue-500"),
 		"textarea": AttrClass("border border-gray-300 mb-2 px-3 py-2 rounded w-full focus:outline-none focus:ring-2 focus:ring-blue-500"),
 
+		"input[type=checkbox]": AttrClass("w-4 h-4 px-0 py-0 mb-0 rounded text-blue-600"),
+		"input[type=radio]":    AttrClass("w-4 h-4 px-0 py-0 mb-0 rounded-full text-blue-600"),
+		"input[type=file]":     AttrClass("bg-white px-0 py-0 border-0 text-sm text-gray-700"),
+
 		// Lists
 		"ul": AttrClass("list-disc pl-5 mb-4"),
 		"ol": AttrClass("list-decimal pl-5 mb-4"),
@@ -96,6 +462,5 @@ func NewDefaultTheme() *Theme {
 		"nav":     AttrClass("flex space-x-4"),
 	}
 
-	theme := Theme(t)
-	return &theme
+	return NewTheme(t)
 }
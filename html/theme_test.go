@@ -0,0 +1,69 @@
+package html
+
+import (
+	"context"
+	"testing"
+)
+
+func TestThemeSelectors(t *testing.T) {
+	theme := NewTheme(map[string]Attrs{
+		"input":                AttrClass("border px-3"),
+		"input[type=checkbox]": AttrClass("px-0"),
+		"[disabled]":           AttrClass("opacity-50"),
+		"input[type":           AttrClass("never"),
+	})
+	c := WithIDGenerator(WithTheme(context.Background(), theme), func(string) string { return "" })
+
+	tests := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{"tag", Input(c, Attrs{"type": "text"}), `<input class="border px-3" type="text" />`},
+		{"more specific wins", Input(c, Attrs{"type": "checkbox"}), `<input class="border px-0" type="checkbox" />`},
+		{"attribute only", Button(c, Attrs{"disabled": "disabled"}), `<button class="opacity-50" disabled="disabled"></button>`},
+		{"no match", Span(c, nil), `<span ></span>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeThemesSchemeStrategy(t *testing.T) {
+	server := NewTheme(nil).WithSchemeStrategy(SchemeServer)
+
+	if got := MergeThemes(server, NewTheme(nil)).SchemeStrategy; got != SchemeServer {
+		t.Errorf("inheriting theme changed the strategy to %v", got)
+	}
+	if got := MergeThemes(server, NewTheme(nil).WithSchemeStrategy(SchemeVariant)).SchemeStrategy; got != SchemeVariant {
+		t.Errorf("strategy = %v, want SchemeVariant set back", got)
+	}
+}
+
+func TestThemeAttrMerge(t *testing.T) {
+	theme := NewTheme(map[string]Attrs{
+		"p": {"class": "text-gray-800", "style": "color:red;margin:0;"},
+	})
+	c := WithIDGenerator(WithTheme(context.Background(), theme), func(string) string { return "" })
+
+	tests := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{"merged", P(c, Attrs{"class": "font-bold", "style": "color:blue"}), `<p class="text-gray-800 font-bold" style="color:blue;margin:0;"></p>`},
+		{"empty class clears", P(c, Attrs{"class": ""}), `<p style="color:red;margin:0;"></p>`},
+		{"empty style clears", P(c, Attrs{"style": ""}), `<p class="text-gray-800"></p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package html
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// unexported key type ensures uniqueness
+type urlPolicyContextKey struct{}
+
+// URLPolicy decides which URLs may appear in URL attributes
+// (href, src, action, formaction, poster, cite and srcset).
+type URLPolicy struct {
+	// Schemes lists the allowed schemes, lowercase. Relative URLs
+	// (no scheme) are always allowed.
+	Schemes []string
+
+	// Fallback replaces a rejected URL.
+	Fallback string
+}
+
+// defaultURLPolicy applies when the context sets no policy. It is never
+// handed out, so it cannot change while documents render.
+var defaultURLPolicy = URLPolicy{
+	Schemes:  []string{"http", "https", "mailto", "tel"},
+	Fallback: "about:invalid#wave-unsafe-url",
+}
+
+// DefaultURLPolicy returns a copy of the policy used when the context
+// sets none: it allows web, mail and phone links, and replaces anything
+// else (javascript:, data:, sms:, ftp:, ...) with a URL that does
+// nothing. Extend it to allow more schemes:
+//
+//	policy := html.DefaultURLPolicy()
+//	policy.Schemes = append(policy.Schemes, "sms")
+//	c = html.WithURLPolicy(c, policy)
+func DefaultURLPolicy() *URLPolicy {
+	p := defaultURLPolicy
+	p.Schemes = slices.Clone(p.Schemes)
+	return &p
+}
+
+// WithURLPolicy returns a new context carrying the given URL policy,
+// e.g. to also allow data: images.
+func WithURLPolicy(ctx context.Context, policy *URLPolicy) context.Context {
+	return context.WithValue(ctx, urlPolicyContextKey{}, policy)
+}
+
+// URLPolicyFromContext retrieves the URL policy from context, if set.
+func URLPolicyFromContext(ctx context.Context) (*URLPolicy, bool) {
+	p, ok := ctx.Value(urlPolicyContextKey{}).(*URLPolicy)
+	return p, ok && p != nil
+}
+
+// urlPolicy returns the policy in context, or the default one.
+func urlPolicy(ctx context.Context) *URLPolicy {
+	if p, ok := URLPolicyFromContext(ctx); ok {
+		return p
+	}
+	return &defaultURLPolicy
+}
+
+// urlAttrs hold a single URL; srcset is handled separately.
+var urlAttrs = tagSet("href", "src", "action", "formaction", "poster", "cite")
+
+// Allowed reports whether rawURL uses an allowed scheme or is relative.
+func (p *URLPolicy) Allowed(rawURL string) bool {
+	scheme, ok := urlScheme(rawURL)
+	return ok && (scheme == "" || slices.Contains(p.Schemes, scheme))
+}
+
+// Sanitize returns rawURL if allowed, or the policy's fallback.
+func (p *URLPolicy) Sanitize(rawURL string) string {
+	if p.Allowed(rawURL) {
+		return rawURL
+	}
+	return p.Fallback
+}
+
+// SanitizeSrcset drops the candidates of a srcset whose URL is not allowed.
+func (p *URLPolicy) SanitizeSrcset(srcset string) string {
+	var kept []string
+	for _, candidate := range parseSrcset(srcset) {
+		if !p.Allowed(candidate.url) {
+			continue
+		}
+		if candidate.descriptors == "" {
+			kept = append(kept, candidate.url)
+		} else {
+			kept = append(kept, candidate.url+" "+candidate.descriptors)
+		}
+	}
+	return strings.Join(kept, ", ")
+}
+
+// srcsetCandidate is one image of a srcset: a URL and its optional
+// width or density descriptors, e.g. "2x".
+type srcsetCandidate struct {
+	url         string
+	descriptors string
+}
+
+// parseSrcset splits a srcset the way browsers do: a candidate's URL
+// runs to the next whitespace, so it may contain commas (data: URLs,
+// "img.jpg?w=1,2"), and its descriptors run to the next comma outside
+// parentheses. Trailing commas on a URL end the candidate.
+func parseSrcset(srcset string) []srcsetCandidate {
+	const space = " \t\n\r\f"
+	var candidates []srcsetCandidate
+	s := srcset
+	for {
+		s = strings.TrimLeft(s, space+",")
+		if s == "" {
+			return candidates
+		}
+
+		end := strings.IndexAny(s, space)
+		if end < 0 {
+			end = len(s)
+		}
+		c := srcsetCandidate{url: s[:end]}
+		s = s[end:]
+
+		if strings.HasSuffix(c.url, ",") {
+			c.url = strings.TrimRight(c.url, ",")
+		} else {
+			depth, i := 0, 0
+		descriptors:
+			for ; i < len(s); i++ {
+				switch s[i] {
+				case '(':
+					depth++
+				case ')':
+					depth = max(depth-1, 0)
+				case ',':
+					if depth == 0 {
+						break descriptors
+					}
+				}
+			}
+			c.descriptors = strings.Join(strings.Fields(s[:i]), " ")
+			s = s[i:]
+		}
+		candidates = append(candidates, c)
+	}
+}
+
+// sanitizeURLAttrs applies the policy to every URL attribute in attrs.
+func (p *URLPolicy) sanitizeURLAttrs(attrs Attrs) {
+	for k, v := range attrs {
+		switch {
+		case urlAttrs[k]:
+			attrs[k] = p.Sanitize(v)
+		case k == "srcset":
+			attrs[k] = p.SanitizeSrcset(v)
+		}
+	}
+}
+
+// urlScheme returns the lowercase scheme of rawURL, "" for relative
+// URLs. Like browsers, it ignores leading spaces and control characters
+// and tabs or newlines anywhere, so "java\tscript:" is still caught.
+// It reports false for URLs it cannot make sense of.
+func urlScheme(rawURL string) (string, bool) {
+	cleaned := strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return -1
+		}
+		return r
+	}, rawURL)
+	cleaned = strings.TrimLeft(cleaned, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x0b\x0c\x0e\x0f\x10\x11\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x1b\x1c\x1d\x1e\x1f ")
+
+	i := strings.IndexAny(cleaned, ":/?#")
+	if i < 0 || cleaned[i] != ':' {
+		return "", true
+	}
+	scheme := strings.ToLower(cleaned[:i])
+	for j, r := range scheme {
+		valid := r >= 'a' && r <= 'z' || j > 0 && (r >= '0' && r <= '9' || r == '+' || r == '-' || r == '.')
+		if !valid {
+			return "", false
+		}
+	}
+	return scheme, scheme != ""
+}
+
+// URL builds a URL from a base and query parameters, encoding the
+// parameters and merging them with any query already on base.
+//
+// Example:
+//
+//	AttrHref(URL("/search", url.Values{"q": {"wave & go"}}))
+//	// href="/search?q=wave+%26+go"
+func URL(base string, query url.Values) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return defaultURLPolicy.Fallback
+	}
+	q := u.Query()
+	for k, vs := range query {
+		for _, v := range vs {
+			q.Add(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
@@ -0,0 +1,122 @@
+package html
+
+import (
+	"context"
+	"testing"
+)
+
+func TestURLPolicyAllowed(t *testing.T) {
+	p := DefaultURLPolicy()
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://example.com", true},
+		{"HTTPS://example.com", true},
+		{"mailto:me@example.com", true},
+		{"/relative/path?q=a:b", true},
+		{"page#section:2", true},
+		{"", true},
+		{"javascript:alert(1)", false},
+		{"JaVaScRiPt:alert(1)", false},
+		{" javascript:alert(1)", false},
+		{"\x00\x1fjavascript:alert(1)", false},
+		{"java\tscript:alert(1)", false},
+		{"java\nscript:alert(1)", false},
+		{"vbscript:msgbox(1)", false},
+		{"data:text/html,<script>x</script>", false},
+		{"sms:+123", false},
+		{"ftp://example.com", false},
+		{"1http://example.com", false},
+		{"java script:alert(1)", false},
+	}
+	for _, tt := range tests {
+		if got := p.Allowed(tt.url); got != tt.want {
+			t.Errorf("Allowed(%q) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultURLPolicyIsACopy(t *testing.T) {
+	p := DefaultURLPolicy()
+	p.Schemes = append(p.Schemes[:0], "javascript")
+	p.Fallback = "#"
+
+	if !DefaultURLPolicy().Allowed("https://example.com") || urlPolicy(context.Background()).Allowed("javascript:x") {
+		t.Error("changing a DefaultURLPolicy copy changed the default")
+	}
+}
+
+func TestSanitizeSrcset(t *testing.T) {
+	p := DefaultURLPolicy()
+	tests := []struct {
+		name, in, want string
+	}{
+		{"descriptors", "a.jpg 1x, b.jpg 2x", "a.jpg 1x, b.jpg 2x"},
+		{"no descriptors", "a.jpg", "a.jpg"},
+		{"extra whitespace", "  a.jpg   480w ,\n b.jpg  800w  ", "a.jpg 480w, b.jpg 800w"},
+		{"comma in url", "img.jpg?w=1,2 1x, img.jpg?w=3,4 2x", "img.jpg?w=1,2 1x, img.jpg?w=3,4 2x"},
+		{"trailing comma ends url", "a.jpg,b.jpg 2x", "a.jpg,b.jpg 2x"},
+		{"url followed by comma", "a.jpg, b.jpg 2x", "a.jpg, b.jpg 2x"},
+		{"javascript dropped", "javascript:alert(1) 1x, b.jpg 2x", "b.jpg 2x"},
+		{"mixed case dropped", "a.jpg 1x, JavaScript:alert(1) 2x", "a.jpg 1x"},
+		{"data url kept whole", "data:image/png;base64,AAAA 1x, b.jpg 2x", "b.jpg 2x"},
+		{"parenthesized descriptor", "a.jpg (x, y) 1x, b.jpg 2x", "a.jpg (x, y) 1x, b.jpg 2x"},
+		{"empty", " , ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.SanitizeSrcset(tt.in); got != tt.want {
+				t.Errorf("SanitizeSrcset(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	data := &URLPolicy{Schemes: []string{"https", "data"}}
+	if got, want := data.SanitizeSrcset("data:image/png;base64,AAAA 1x, https://cdn.example/a.jpg?w=1,2 2x"), "data:image/png;base64,AAAA 1x, https://cdn.example/a.jpg?w=1,2 2x"; got != want {
+		t.Errorf("SanitizeSrcset() = %q, want %q", got, want)
+	}
+}
+
+func TestElementURLAttrs(t *testing.T) {
+	c := WithIDGenerator(context.Background(), func(string) string { return "" })
+	tests := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{
+			"javascript href",
+			A(c, AttrHref("javascript:alert(1)")),
+			`<a href="about:invalid#wave-unsafe-url"></a>`,
+		},
+		{
+			"mixed case with leading control characters",
+			A(c, AttrHref("\x01 JAVASCRIPT:alert(1)")),
+			`<a href="about:invalid#wave-unsafe-url"></a>`,
+		},
+		{
+			// The value is escaped, so the browser sees the entity as text
+			"entity-encoded scheme stays inert",
+			A(c, AttrHref("&#106;avascript:alert(1)")),
+			`<a href="&amp;#106;avascript:alert(1)"></a>`,
+		},
+		{
+			"srcset",
+			Img(c, Attributes(AttrSrc("/a.jpg"), AttrSrcset("javascript:x 1x, /b.jpg?w=1,2 2x"))),
+			`<img src="/a.jpg" srcset="/b.jpg?w=1,2 2x" />`,
+		},
+		{
+			"policy from context",
+			A(WithURLPolicy(c, &URLPolicy{Schemes: []string{"sms"}, Fallback: "#"}), AttrHref("https://example.com")),
+			`<a href="#"></a>`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
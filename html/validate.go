@@ -0,0 +1,478 @@
+package html
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/GopherGhaznix/Wave/css"
+)
+
+// unexported key type ensures uniqueness
+type validatorContextKey struct{}
+
+// ViolationKind classifies a conformance problem found while rendering.
+type ViolationKind string
+
+const (
+	ViolationContent   ViolationKind = "content"   // invalid parent/child combination
+	ViolationAttribute ViolationKind = "attribute" // attribute unknown for the element
+	ViolationObsolete  ViolationKind = "obsolete"  // element removed from HTML5
+	ViolationMisuse    ViolationKind = "misuse"    // one Validator used by concurrent renders
+)
+
+// Violation describes a single HTML5 conformance problem.
+type Violation struct {
+	Kind    ViolationKind
+	Tag     string
+	Parent  string // enclosing element, empty at the root
+	Attr    string // offending attribute, only for ViolationAttribute
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("wave: %s: <%s>: %s", v.Kind, v.Tag, v.Message)
+}
+
+// Validator checks rendered elements against the HTML5 content model.
+// It is carried in the context, so development builds can enable it
+// with WithValidator while production builds simply leave it out.
+//
+// Parent/child relationships are tracked while the tree renders, so a
+// Validator must only be used by one render at a time: keep a base
+// Validator in the base context and give every request its own Fork.
+// Sharing one is caught on a best-effort basis only: renders that close
+// elements out of order are reported as ViolationMisuse, but renders
+// that happen to enter and leave the same tags in step go unnoticed
+// while still mixing up their parents.
+//
+// Example:
+//
+//	base := html.NewValidator(html.LogViolation)
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		c := html.WithValidator(r.Context(), base.Fork())
+//		fmt.Fprint(w, page(c)())
+//	}
+type Validator struct {
+	// Report is called for every violation as it is found.
+	// When nil, violations are only collected.
+	Report func(Violation)
+
+	// AttrPrefixes lists more attribute prefixes accepted on every
+	// element, e.g. "v-" for Vue. Besides the standard data-, aria- and
+	// on* attributes and RDFa ones like <meta property>, the htmx
+	// (hx-, sse-, ws-) and Alpine.js (x-, :, @) prefixes are accepted.
+	AttrPrefixes []string
+
+	// CheckStyles reports style declarations with unknown properties.
+	// It is off by default, as package css only knows the common ones.
+	CheckStyles bool
+
+	mu         sync.Mutex
+	stack      []string
+	violations []Violation
+}
+
+// NewValidator returns a Validator that reports through the given func.
+func NewValidator(report func(Violation)) *Validator {
+	return &Validator{Report: report}
+}
+
+// LogViolation is a Report func that writes violations to the standard logger.
+func LogViolation(v Violation) {
+	log.Println(v.String())
+}
+
+// Fork returns a Validator for a single render. Its violations are also
+// collected and reported by v, so one base Validator sees every render.
+func (v *Validator) Fork() *Validator {
+	return &Validator{
+		Report:       v.add,
+		AttrPrefixes: v.AttrPrefixes,
+		CheckStyles:  v.CheckStyles,
+	}
+}
+
+// WithValidator returns a new context carrying the given validator.
+func WithValidator(ctx context.Context, v *Validator) context.Context {
+	return context.WithValue(ctx, validatorContextKey{}, v)
+}
+
+// ValidatorFromContext retrieves the validator from context, if set.
+func ValidatorFromContext(ctx context.Context) (*Validator, bool) {
+	v, ok := ctx.Value(validatorContextKey{}).(*Validator)
+	return v, ok
+}
+
+// Violations returns all violations collected so far.
+func (v *Validator) Violations() []Violation {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Clone(v.violations)
+}
+
+// Reset clears collected violations.
+func (v *Validator) Reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.violations = nil
+	v.stack = nil
+}
+
+// enter validates an element about to render and pushes it as the
+// parent of the children rendered next. It must be paired with leave.
+func (v *Validator) enter(tag string, attrs Attrs, hasChildren bool) {
+	v.mu.Lock()
+	ancestors := slices.Clone(v.stack)
+	v.stack = append(v.stack, tag)
+	v.mu.Unlock()
+
+	for _, violation := range v.checkElement(tag, attrs, hasChildren, ancestors) {
+		v.add(violation)
+	}
+}
+
+// leave pops tag. Finding another element on top means a second render
+// is using the same Validator, which makes parents unreliable.
+func (v *Validator) leave(tag string) {
+	v.mu.Lock()
+	i := -1
+	for j := len(v.stack) - 1; j >= 0; j-- {
+		if v.stack[j] == tag {
+			i = j
+			break
+		}
+	}
+	interleaved := i >= 0 && i != len(v.stack)-1
+	if i >= 0 {
+		v.stack = slices.Delete(v.stack, i, i+1)
+	}
+	v.mu.Unlock()
+
+	if interleaved {
+		v.add(Violation{
+			Kind:    ViolationMisuse,
+			Tag:     tag,
+			Message: "validator shared by concurrent renders; give each render its own (see Validator.Fork)",
+		})
+	}
+}
+
+// ancestors returns the elements currently rendering, outermost first.
+func (v *Validator) ancestors() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return slices.Clone(v.stack)
+}
+
+// within runs render as if nested inside ancestors, for content rendered
+// apart from its parents (see Suspense).
+func (v *Validator) within(ancestors []string, render func() string) string {
+	v.mu.Lock()
+	saved := v.stack
+	v.stack = slices.Clone(ancestors)
+	v.mu.Unlock()
+
+	defer func() {
+		v.mu.Lock()
+		v.stack = saved
+		v.mu.Unlock()
+	}()
+	return render()
+}
+
+func (v *Validator) add(violation Violation) {
+	v.mu.Lock()
+	v.violations = append(v.violations, violation)
+	v.mu.Unlock()
+
+	if v.Report != nil {
+		v.Report(violation)
+	}
+}
+
+// checkElement runs every rule for tag given its ancestors (outermost first).
+func (v *Validator) checkElement(tag string, attrs Attrs, hasChildren bool, ancestors []string) []Violation {
+	var out []Violation
+	parent := ""
+	if len(ancestors) > 0 {
+		parent = ancestors[len(ancestors)-1]
+	}
+	violation := func(kind ViolationKind, attr, format string, args ...any) {
+		out = append(out, Violation{
+			Kind:    kind,
+			Tag:     tag,
+			Parent:  parent,
+			Attr:    attr,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if obsoleteElements[tag] {
+		violation(ViolationObsolete, "", "element is obsolete in HTML5")
+	}
+
+	if voidElements[tag] && hasChildren {
+		violation(ViolationContent, "", "void element cannot have children")
+	}
+
+	// Report only the most specific placement problem
+	parents, hasRequired := requiredParents[tag]
+	children, hasAllowed := allowedChildren[parent]
+	container := phrasingContainer(ancestors)
+	switch {
+	case hasRequired && !slices.Contains(parents, parent):
+		violation(ViolationContent, "", "must be a child of <%s>, found inside %s", strings.Join(parents, ">, <"), describeParent(parent))
+	case hasAllowed && !slices.Contains(children, tag):
+		violation(ViolationContent, "", "not allowed as a child of <%s>", parent)
+	case container != "" && !phrasingElements[tag]:
+		violation(ViolationContent, "", "flow content not allowed inside <%s>, which only accepts phrasing content", container)
+	}
+
+	if interactiveElements[tag] {
+		for _, a := range ancestors {
+			if a == "a" || a == "button" {
+				violation(ViolationContent, "", "interactive content not allowed inside <%s>", a)
+				break
+			}
+		}
+	}
+
+	if tag == "form" && slices.Contains(ancestors, "form") {
+		violation(ViolationContent, "", "forms cannot be nested")
+	}
+
+	if tag == "area" && !slices.Contains(ancestors, "map") {
+		violation(ViolationContent, "", "must be inside <map>")
+	}
+
+	for name, value := range attrs {
+		if value == "" || isKnownAttr(tag, name, v.AttrPrefixes) {
+			continue
+		}
+		violation(ViolationAttribute, name, "unknown attribute %q", name)
+	}
+
+	if v.CheckStyles {
+		if err := css.ParseInline(attrs["style"]).Validate(); err != nil {
+			violation(ViolationAttribute, "style", "%v", err)
+		}
+	}
+
+	return out
+}
+
+func describeParent(parent string) string {
+	if parent == "" {
+		return "the root"
+	}
+	return "<" + parent + ">"
+}
+
+// phrasingContainer returns the nearest ancestor that only accepts
+// phrasing content, skipping transparent elements like <a> and <ins>.
+// It returns "" when flow content is allowed.
+func phrasingContainer(ancestors []string) string {
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		a := ancestors[i]
+		if transparentElements[a] {
+			continue
+		}
+		if phrasingOnlyElements[a] {
+			return a
+		}
+		return ""
+	}
+	return ""
+}
+
+// attrPrefixes are accepted on every element: the standard data-, aria-
+// and event handler attributes, then htmx and Alpine.js ones.
+var attrPrefixes = []string{"data-", "aria-", "on", "hx-", "sse-", "ws-", "x-", ":", "@"}
+
+func isKnownAttr(tag, name string, extraPrefixes []string) bool {
+	if globalAttrs[name] || rdfaAttrs[name] {
+		return true
+	}
+	for _, prefixes := range [][]string{attrPrefixes, extraPrefixes} {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		}
+	}
+	return slices.Contains(elementAttrs[tag], name)
+}
+
+// -----------------------
+// HTML5 Content Model
+// -----------------------
+
+func tagSet(items ...string) map[string]bool {
+	m := make(map[string]bool, len(items))
+	for _, item := range items {
+		m[item] = true
+	}
+	return m
+}
+
+var voidElements = tagSet(
+	"area", "base", "br", "col", "embed", "hr", "img", "input",
+	"link", "meta", "param", "source", "track", "wbr",
+)
+
+var phrasingElements = tagSet(
+	"a", "abbr", "area", "audio", "b", "bdi", "bdo", "br", "button",
+	"canvas", "cite", "code", "data", "datalist", "del", "dfn", "em",
+	"embed", "i", "iframe", "img", "input", "ins", "kbd", "label", "link",
+	"map", "mark", "meta", "meter", "noscript", "object", "output",
+	"picture", "progress", "q", "ruby", "s", "samp", "script", "select",
+	"small", "span", "strong", "sub", "sup", "template", "textarea",
+	"time", "u", "var", "video", "wbr",
+	// obsolete, but inline; reported separately
+	"acronym", "big", "nobr",
+)
+
+var phrasingOnlyElements = tagSet(
+	"abbr", "b", "bdi", "bdo", "button", "cite", "code", "data", "dfn",
+	"em", "h1", "h2", "h3", "h4", "h5", "h6", "i", "kbd", "label",
+	"legend", "mark", "meter", "output", "p", "pre", "progress", "q",
+	"rt", "s", "samp", "small", "span", "strong", "sub", "sup", "time",
+	"u", "var", "acronym", "big", "nobr",
+)
+
+var transparentElements = tagSet(
+	"a", "audio", "canvas", "del", "ins", "map", "noscript", "object",
+	"video",
+)
+
+var interactiveElements = tagSet(
+	"a", "button", "details", "embed", "iframe", "label", "select",
+	"textarea",
+)
+
+var requiredParents = map[string][]string{
+	"li":         {"ul", "ol", "menu", "dir"},
+	"dt":         {"dl", "div"},
+	"dd":         {"dl", "div"},
+	"tr":         {"thead", "tbody", "tfoot"},
+	"td":         {"tr"},
+	"th":         {"tr"},
+	"thead":      {"table"},
+	"tbody":      {"table"},
+	"tfoot":      {"table"},
+	"caption":    {"table"},
+	"colgroup":   {"table"},
+	"col":        {"colgroup"},
+	"option":     {"select", "datalist", "optgroup"},
+	"optgroup":   {"select"},
+	"legend":     {"fieldset"},
+	"figcaption": {"figure"},
+	"summary":    {"details"},
+	"source":     {"audio", "video", "picture"},
+	"track":      {"audio", "video"},
+	"param":      {"object"},
+	"rt":         {"ruby"},
+	"rp":         {"ruby"},
+	"rb":         {"ruby"},
+	"head":       {"html"},
+	"body":       {"html"},
+	"title":      {"head"},
+	"base":       {"head"},
+}
+
+var allowedChildren = map[string][]string{
+	"html":     {"head", "body"},
+	"ul":       {"li", "script", "template"},
+	"ol":       {"li", "script", "template"},
+	"menu":     {"li", "script", "template"},
+	"dl":       {"dt", "dd", "div", "script", "template"},
+	"table":    {"caption", "colgroup", "thead", "tbody", "tfoot", "script", "template"},
+	"thead":    {"tr", "script", "template"},
+	"tbody":    {"tr", "script", "template"},
+	"tfoot":    {"tr", "script", "template"},
+	"tr":       {"td", "th", "script", "template"},
+	"colgroup": {"col", "template"},
+	"select":   {"option", "optgroup", "hr", "script", "template"},
+	"optgroup": {"option", "script", "template"},
+	"datalist": {"option", "script", "template"},
+	"picture":  {"source", "img", "script", "template"},
+}
+
+var globalAttrs = tagSet(
+	"accesskey", "anchor", "autocapitalize", "autocorrect", "autofocus",
+	"class", "contenteditable", "dir", "draggable", "elementtiming",
+	"enterkeyhint", "exportparts", "hidden", "id", "inert", "inputmode",
+	"is", "itemid", "itemprop", "itemref", "itemscope", "itemtype", "lang",
+	"nonce", "part", "popover", "role", "slot", "spellcheck", "style",
+	"tabindex", "title", "translate", "virtualkeyboardpolicy",
+	"writingsuggestions",
+)
+
+// rdfaAttrs may appear on any element, e.g. Open Graph's <meta property>.
+var rdfaAttrs = tagSet(
+	"about", "datatype", "inlist", "prefix", "property", "resource",
+	"rev", "typeof", "vocab",
+)
+
+var elementAttrs = map[string][]string{
+	"a":          {"href", "target", "download", "ping", "rel", "hreflang", "type", "referrerpolicy"},
+	"area":       {"alt", "coords", "shape", "href", "target", "download", "ping", "rel", "referrerpolicy"},
+	"audio":      {"src", "crossorigin", "preload", "autoplay", "loop", "muted", "controls"},
+	"base":       {"href", "target"},
+	"blockquote": {"cite"},
+	"button": {"disabled", "form", "formaction", "formenctype", "formmethod", "formnovalidate",
+		"formtarget", "name", "popovertarget", "popovertargetaction", "type", "value"},
+	"canvas":   {"width", "height"},
+	"col":      {"span"},
+	"colgroup": {"span"},
+	"data":     {"value"},
+	"del":      {"cite", "datetime"},
+	"details":  {"open", "name"},
+	"dialog":   {"open"},
+	"embed":    {"src", "type", "width", "height"},
+	"fieldset": {"disabled", "form", "name"},
+	"form":     {"accept-charset", "action", "autocomplete", "enctype", "method", "name", "novalidate", "target", "rel"},
+	"html":     {"xmlns"},
+	"iframe":   {"src", "srcdoc", "name", "sandbox", "allow", "allowfullscreen", "width", "height", "referrerpolicy", "loading"},
+	"img": {"alt", "src", "srcset", "sizes", "crossorigin", "usemap", "ismap", "width", "height",
+		"referrerpolicy", "decoding", "loading", "fetchpriority"},
+	"input": {"accept", "alt", "autocomplete", "capture", "checked", "dirname", "disabled", "form",
+		"formaction", "formenctype", "formmethod", "formnovalidate", "formtarget", "height", "list",
+		"max", "maxlength", "min", "minlength", "multiple", "name", "pattern", "placeholder",
+		"popovertarget", "popovertargetaction", "readonly", "required", "size", "src", "step",
+		"type", "value", "width"},
+	"ins":   {"cite", "datetime"},
+	"label": {"for"},
+	"li":    {"value"},
+	"link": {"href", "crossorigin", "rel", "media", "integrity", "hreflang", "type", "referrerpolicy",
+		"sizes", "imagesrcset", "imagesizes", "as", "blocking", "color", "disabled", "fetchpriority"},
+	"map":      {"name"},
+	"meta":     {"name", "http-equiv", "content", "charset", "media"},
+	"meter":    {"value", "min", "max", "low", "high", "optimum"},
+	"object":   {"data", "type", "name", "form", "width", "height"},
+	"ol":       {"reversed", "start", "type"},
+	"optgroup": {"disabled", "label"},
+	"option":   {"disabled", "label", "selected", "value"},
+	"output":   {"for", "form", "name"},
+	"param":    {"name", "value"},
+	"progress": {"value", "max"},
+	"q":        {"cite"},
+	"script":   {"src", "type", "nomodule", "async", "defer", "crossorigin", "integrity", "referrerpolicy", "blocking", "fetchpriority"},
+	"select":   {"autocomplete", "disabled", "form", "multiple", "name", "required", "size"},
+	"source":   {"type", "media", "src", "srcset", "sizes", "width", "height"},
+	"style":    {"media", "blocking"},
+	"td":       {"colspan", "rowspan", "headers"},
+	"template": {"shadowrootmode", "shadowrootdelegatesfocus", "shadowrootclonable", "shadowrootserializable"},
+	"textarea": {"autocomplete", "cols", "dirname", "disabled", "form", "maxlength", "minlength", "name",
+		"placeholder", "readonly", "required", "rows", "wrap"},
+	"th":    {"colspan", "rowspan", "headers", "scope", "abbr"},
+	"time":  {"datetime"},
+	"track": {"default", "kind", "label", "src", "srclang"},
+	"video": {"src", "crossorigin", "poster", "preload", "autoplay", "playsinline", "loop", "muted",
+		"controls", "width", "height"},
+}
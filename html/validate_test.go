@@ -0,0 +1,66 @@
+package html
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidatorAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		node    func(c context.Context) Node
+		flagged []string
+	}{
+		{"open graph", func(c context.Context) Node {
+			return Meta(c, Attrs{"property": "og:title", "content": "Wave"})
+		}, nil},
+		{"htmx", func(c context.Context) Node {
+			return Button(c, Attrs{"hx-post": "/save", "hx-target": "#out", "hx-on::after-request": "done()"})
+		}, nil},
+		{"alpine", func(c context.Context) Node {
+			return Div(c, Attrs{"x-data": "{open: false}", "@click": "open = true", ":class": "open && 'block'"})
+		}, nil},
+		{"unknown", func(c context.Context) Node {
+			return Div(c, Attrs{"v-if": "open", "colour": "red"})
+		}, []string{"colour", "v-if"}},
+		{"unknown css property is not checked", func(c context.Context) Node {
+			return Div(c, Attrs{"style": "text-wrap-style: pretty"})
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator(nil)
+			tt.node(WithValidator(context.Background(), v))()
+			var got []string
+			for _, violation := range v.Violations() {
+				got = append(got, violation.Attr)
+			}
+			if len(got) != len(tt.flagged) {
+				t.Fatalf("flagged %v, want %v", got, tt.flagged)
+			}
+			for _, attr := range tt.flagged {
+				found := false
+				for _, g := range got {
+					found = found || g == attr
+				}
+				if !found {
+					t.Errorf("flagged %v, want %v", got, tt.flagged)
+				}
+			}
+		})
+	}
+}
+
+func TestValidatorForkOptions(t *testing.T) {
+	base := NewValidator(nil)
+	base.AttrPrefixes = []string{"v-"}
+	base.CheckStyles = true
+
+	c := WithValidator(context.Background(), base.Fork())
+	Div(c, Attrs{"v-if": "open", "style": "colr: red"})()
+
+	got := base.Violations()
+	if len(got) != 1 || got[0].Attr != "style" {
+		t.Errorf("violations %v, want only the unknown style property", got)
+	}
+}
@@ -0,0 +1,314 @@
+package site
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/GopherGhaznix/Wave/assets"
+	"github.com/GopherGhaznix/Wave/html"
+)
+
+// Page renders the content of a route.
+type Page func(c context.Context) html.Node
+
+// Route maps a URL path to a page.
+//
+// "/" and "/docs" are written as index.html and docs/index.html;
+// paths with an extension, like "/404.html", are written as-is.
+type Route struct {
+	Path string
+	Page Page
+
+	// NoSitemap leaves the route out of sitemap.xml.
+	NoSitemap bool
+}
+
+// Config describes a site build.
+type Config struct {
+	// OutDir is the output directory, "dist" by default.
+	OutDir string
+
+	// BaseURL is the public origin, e.g. "https://example.com".
+	// sitemap.xml is only generated when it is set.
+	BaseURL string
+
+	Routes []Route
+
+	// Static files are copied as-is to the root of OutDir.
+	Static fs.FS
+
+	// Assets are copied under their Prefix, by plain and hashed name,
+	// and the manifest is carried in every page's context.
+	Assets *assets.Manifest
+
+	// Context is the base context for every page, e.g. carrying a theme.
+	Context context.Context
+
+	// Incremental skips files whose content is unchanged since the last
+	// build and removes files the build no longer produces.
+	Incremental bool
+}
+
+// Result lists what a build did, as paths relative to OutDir.
+type Result struct {
+	Written   []string
+	Unchanged []string
+	Removed   []string
+}
+
+// stateFile records the content hash of every file of the last build.
+const stateFile = ".wave-build.json"
+
+// builder writes output files and tracks their hashes.
+type builder struct {
+	cfg      Config
+	previous map[string]string
+	current  map[string]string
+	sources  map[string]string // what produced each file, for collisions
+	result   Result
+}
+
+// Build renders every route to OutDir, copies static files and assets,
+// and writes sitemap.xml. It fails when two of them would write the same
+// file, e.g. a static index.html and the "/" route.
+//
+// Pages render with sequential element ids (see html.SequentialIDs), so
+// unchanged pages produce identical files and incremental builds can
+// skip them.
+func Build(cfg Config) (*Result, error) {
+	if cfg.OutDir == "" {
+		cfg.OutDir = "dist"
+	}
+	if cfg.Context == nil {
+		cfg.Context = context.Background()
+	}
+	if cfg.Assets != nil {
+		cfg.Context = assets.WithManifest(cfg.Context, cfg.Assets)
+	}
+
+	for _, r := range cfg.Routes {
+		if r.Page == nil {
+			return nil, fmt.Errorf("site: route %q has no page", r.Path)
+		}
+	}
+
+	b := &builder{
+		cfg:      cfg,
+		previous: map[string]string{},
+		current:  map[string]string{},
+		sources:  map[string]string{},
+	}
+	if cfg.Incremental {
+		if data, err := os.ReadFile(filepath.Join(cfg.OutDir, stateFile)); err == nil {
+			if err := json.Unmarshal(data, &b.previous); err != nil {
+				return nil, fmt.Errorf("site: %s: %w", stateFile, err)
+			}
+		}
+	}
+
+	steps := []func() error{b.pages, b.static, b.assets, b.sitemap, b.finish}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, fmt.Errorf("site: %w", err)
+		}
+	}
+	return &b.result, nil
+}
+
+// OutputPath returns the file a route path is written to.
+func OutputPath(routePath string) string {
+	p := strings.Trim(path.Clean("/"+routePath), "/")
+	if path.Ext(p) != "" {
+		return p
+	}
+	return path.Join(p, "index.html")
+}
+
+func (b *builder) pages() error {
+	for _, r := range b.cfg.Routes {
+		c := html.WithIDGenerator(b.cfg.Context, html.SequentialIDs("wave"))
+		node := r.Page(c)
+		if node == nil {
+			return fmt.Errorf("route %q rendered no page", r.Path)
+		}
+		rendered := node()
+		if strings.HasPrefix(rendered, "<html") {
+			rendered = "<!DOCTYPE html>\n" + rendered
+		}
+		source := fmt.Sprintf("route %q", r.Path)
+		if err := b.write(source, OutputPath(r.Path), []byte(rendered+"\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builder) static() error {
+	if b.cfg.Static == nil {
+		return nil
+	}
+	return fs.WalkDir(b.cfg.Static, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(b.cfg.Static, name)
+		if err != nil {
+			return err
+		}
+		return b.write(fmt.Sprintf("static file %q", name), name, data)
+	})
+}
+
+func (b *builder) assets() error {
+	m := b.cfg.Assets
+	if m == nil {
+		return nil
+	}
+	dir := strings.Trim(m.Prefix, "/")
+	for _, a := range m.Assets() {
+		f, err := m.Open(a.Name)
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		for _, name := range []string{a.Name, a.HashedName} {
+			if err := b.write(fmt.Sprintf("asset %q", a.Name), path.Join(dir, name), data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// sitemapURLSet is the sitemaps.org <urlset> document.
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc string `xml:"loc"`
+}
+
+func (b *builder) sitemap() error {
+	if b.cfg.BaseURL == "" {
+		return nil
+	}
+	set := sitemapURLSet{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	base := strings.TrimSuffix(b.cfg.BaseURL, "/")
+	for _, r := range b.cfg.Routes {
+		if !r.NoSitemap {
+			set.URLs = append(set.URLs, sitemapURL{Loc: base + path.Clean("/"+r.Path)})
+		}
+	}
+	data, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return err
+	}
+	return b.write("the sitemap", "sitemap.xml", append([]byte(xml.Header), append(data, '\n')...))
+}
+
+// finish removes stale files and records the hashes for the next build.
+func (b *builder) finish() error {
+	if b.cfg.Incremental {
+		for _, name := range slices.Sorted(maps.Keys(b.previous)) {
+			if _, ok := b.current[name]; ok {
+				continue
+			}
+			err := os.Remove(filepath.Join(b.cfg.OutDir, filepath.FromSlash(name)))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+			b.result.Removed = append(b.result.Removed, name)
+		}
+	}
+	data, err := json.MarshalIndent(b.current, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(b.cfg.OutDir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(b.cfg.OutDir, stateFile), data, 0o644)
+}
+
+// write writes a file under OutDir, unless an incremental build finds
+// it unchanged. It fails when another source already wrote the same
+// file, e.g. a static file shadowing a page.
+func (b *builder) write(source, name string, data []byte) error {
+	if other, ok := b.sources[name]; ok {
+		return fmt.Errorf("%s and %s both write %s", other, source, name)
+	}
+	b.sources[name] = source
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	b.current[name] = hash
+
+	target := filepath.Join(b.cfg.OutDir, filepath.FromSlash(name))
+	if b.cfg.Incremental && b.previous[name] == hash {
+		if _, err := os.Stat(target); err == nil {
+			b.result.Unchanged = append(b.result.Unchanged, name)
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return err
+	}
+	b.result.Written = append(b.result.Written, name)
+	return nil
+}
+
+// -----------------------
+// Command Line
+// -----------------------
+
+// Main builds the site from a program's main function, letting flags
+// override the config. It is what "wave build" runs.
+//
+// Example:
+//
+//	func main() {
+//		site.Main(site.Config{Routes: []site.Route{
+//			{Path: "/", Page: home},
+//			{Path: "/about", Page: about},
+//		}})
+//	}
+func Main(cfg Config) {
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags.StringVar(&cfg.OutDir, "out", cfg.OutDir, "output directory")
+	flags.StringVar(&cfg.BaseURL, "base-url", cfg.BaseURL, "public origin for sitemap.xml")
+	flags.BoolVar(&cfg.Incremental, "incremental", cfg.Incremental, "only write changed files")
+	flags.Parse(os.Args[1:])
+
+	res, err := Build(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "site: %d written, %d unchanged, %d removed\n",
+		len(res.Written), len(res.Unchanged), len(res.Removed))
+}
@@ -0,0 +1,105 @@
+package site
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/GopherGhaznix/Wave/html"
+)
+
+func page(text string) Page {
+	return func(c context.Context) html.Node { return html.P(c, nil, html.Text(text)) }
+}
+
+func TestBuild(t *testing.T) {
+	out := t.TempDir()
+	cfg := Config{
+		OutDir:  out,
+		BaseURL: "https://example.com/",
+		Routes: []Route{
+			{Path: "/", Page: page("home")},
+			{Path: "/docs", Page: page("docs")},
+			{Path: "/404.html", Page: page("missing"), NoSitemap: true},
+		},
+		Static:      fstest.MapFS{"robots.txt": {Data: []byte("User-agent: *\n")}},
+		Incremental: true,
+	}
+
+	res, err := Build(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(res.Written); got != 5 {
+		t.Errorf("wrote %v, want 5 files", res.Written)
+	}
+	data, err := os.ReadFile(filepath.Join(out, "docs", "index.html"))
+	if err != nil || !strings.Contains(string(data), "docs") {
+		t.Errorf("docs/index.html = %q, %v", data, err)
+	}
+	sitemap, _ := os.ReadFile(filepath.Join(out, "sitemap.xml"))
+	if !strings.Contains(string(sitemap), "<loc>https://example.com/docs</loc>") || strings.Contains(string(sitemap), "404") {
+		t.Errorf("sitemap.xml =\n%s", sitemap)
+	}
+
+	// Pages render with sequential ids, so a second build changes nothing
+	res, err = Build(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Written) != 0 || len(res.Unchanged) != 5 {
+		t.Errorf("rebuild wrote %v, kept %v; want every file unchanged", res.Written, res.Unchanged)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			"nil page",
+			Config{Routes: []Route{{Path: "/"}}},
+			`route "/" has no page`,
+		},
+		{
+			"nil node",
+			Config{Routes: []Route{{Path: "/", Page: func(context.Context) html.Node { return nil }}}},
+			`route "/" rendered no page`,
+		},
+		{
+			"duplicate routes",
+			Config{Routes: []Route{{Path: "/docs", Page: page("a")}, {Path: "/docs/", Page: page("b")}}},
+			`route "/docs" and route "/docs/" both write docs/index.html`,
+		},
+		{
+			"static shadows page",
+			Config{
+				Routes: []Route{{Path: "/", Page: page("home")}},
+				Static: fstest.MapFS{"index.html": {Data: []byte("static")}},
+			},
+			`route "/" and static file "index.html" both write index.html`,
+		},
+		{
+			"static shadows sitemap",
+			Config{
+				BaseURL: "https://example.com",
+				Static:  fstest.MapFS{"sitemap.xml": {Data: []byte("<urlset/>")}},
+			},
+			`static file "sitemap.xml" and the sitemap both write sitemap.xml`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.OutDir = t.TempDir()
+			_, err := Build(tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Build() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
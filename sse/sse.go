@@ -0,0 +1,186 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GopherGhaznix/Wave/html"
+)
+
+// ErrStreamingUnsupported is returned when the ResponseWriter cannot flush.
+var ErrStreamingUnsupported = errors.New("sse: streaming unsupported")
+
+// Event is a single Server-Sent Event.
+type Event struct {
+	// Event is the event type; empty means the default "message".
+	Event string
+
+	// ID is stored by the browser and sent back as Last-Event-ID when
+	// it reconnects.
+	ID string
+
+	// Data is the payload; each line becomes its own "data:" field.
+	Data string
+
+	// Retry tells the browser how long to wait before reconnecting.
+	Retry time.Duration
+}
+
+// InvalidFieldError reports an event type or id that cannot be framed.
+type InvalidFieldError struct {
+	Field string
+	Value string
+}
+
+func (e *InvalidFieldError) Error() string {
+	return fmt.Sprintf("sse: %s %q must be a single line", e.Field, e.Value)
+}
+
+// Writer frames events onto an HTTP response. It is safe for concurrent
+// use, so a heartbeat can run alongside the handler.
+type Writer struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+// NewWriter sets the event stream headers and returns a Writer.
+//
+// Example:
+//
+//	func events(w http.ResponseWriter, r *http.Request) {
+//		stream, err := sse.NewWriter(w)
+//		if err != nil {
+//			http.Error(w, err.Error(), http.StatusInternalServerError)
+//			return
+//		}
+//		stop := stream.Heartbeat(r.Context(), 15*time.Second)
+//		defer stop()
+//		for update := range updates(r.Context(), sse.LastEventID(r)) {
+//			stream.SendNode("update", update.ID, widget(c, update))
+//		}
+//	}
+func NewWriter(w http.ResponseWriter) (*Writer, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, ErrStreamingUnsupported
+	}
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no") // disable proxy buffering (nginx)
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	return &Writer{w: w, flusher: flusher}, nil
+}
+
+// Send writes a single event and flushes it.
+func (w *Writer) Send(e Event) error {
+	frame, err := e.frame()
+	if err != nil {
+		return err
+	}
+	return w.write(frame)
+}
+
+// SendNode renders a Wave node as the data of an event. The node's
+// multi-line output is split into one "data:" field per line, which the
+// browser joins back with newlines.
+func (w *Writer) SendNode(event, id string, node html.Node) error {
+	return w.Send(Event{Event: event, ID: id, Data: node()})
+}
+
+// Comment writes a comment line, which browsers ignore.
+func (w *Writer) Comment(text string) error {
+	var sb strings.Builder
+	for _, line := range splitLines(text) {
+		sb.WriteString(": " + line + "\n")
+	}
+	sb.WriteString("\n")
+	return w.write(sb.String())
+}
+
+// Heartbeat sends a comment every interval until ctx is done or stop is
+// called, keeping idle connections open through proxies and detecting
+// gone clients. The handler must call stop before returning: it waits
+// for the heartbeat to finish, as a ResponseWriter cannot be used after
+// ServeHTTP returns.
+func (w *Writer) Heartbeat(ctx context.Context, interval time.Duration) (stop func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if w.Comment("heartbeat") != nil {
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (w *Writer) write(frame string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.w.Write([]byte(frame)); err != nil {
+		return err
+	}
+	w.flusher.Flush()
+	return nil
+}
+
+// frame encodes the event in the text/event-stream format.
+func (e Event) frame() (string, error) {
+	if strings.ContainsAny(e.Event, "\r\n") {
+		return "", &InvalidFieldError{Field: "event", Value: e.Event}
+	}
+	if strings.ContainsAny(e.ID, "\r\n\x00") {
+		return "", &InvalidFieldError{Field: "id", Value: e.ID}
+	}
+
+	var sb strings.Builder
+	if e.Event != "" {
+		sb.WriteString("event: " + e.Event + "\n")
+	}
+	if e.ID != "" {
+		sb.WriteString("id: " + e.ID + "\n")
+	}
+	if e.Retry > 0 {
+		sb.WriteString("retry: " + strconv.FormatInt(e.Retry.Milliseconds(), 10) + "\n")
+	}
+	for _, line := range splitLines(e.Data) {
+		sb.WriteString("data: " + line + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String(), nil
+}
+
+// splitLines splits on any of the line endings the format accepts.
+func splitLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.Split(s, "\n")
+}
+
+// LastEventID returns the id of the last event the browser received
+// before reconnecting, or "" on the first connection.
+func LastEventID(r *http.Request) string {
+	return r.Header.Get("Last-Event-ID")
+}